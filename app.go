@@ -1,9 +1,15 @@
 package TurboGo
 
 import (
+	"context"
 	"fmt"
+	"path/filepath"
+	"reflect"
 	"runtime"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Dziqha/TurboGo/core"
@@ -27,6 +33,10 @@ func CenterText(text string) string {
 }
 
 func Banner(addr string) string {
+	return banner("http://localhost" + addr)
+}
+
+func banner(url string) string {
 	return `
  _____            _             ___      
 /__   \_   _ _ __| |__   ___   / _ \___  
@@ -36,33 +46,81 @@ func Banner(addr string) string {
                                          
 ` +
 		CenterText("🌀 TurboGo Ultra High-Performance Web Framework") + "\n" +
-		CenterText(fmt.Sprintf("⚡ Listening on: http://localhost%s", addr)) + "\n"
+		CenterText(fmt.Sprintf("⚡ Listening on: %s", url)) + "\n"
 }
 
 type App struct {
 	routes     []*router.Route
+	routeIndex map[string]*router.Route
 	middleware []core.Handler
+	mwNames    []string // function names of middleware, for listings
+	showRoutes bool
+
+	strictRouting bool
 
 	singleRouter *router.SingleThreadedRouter
+	hosts        []*hostRoute
+	notFounds    []scopedHandler
+	notFound     core.Handler
+	onError      core.ErrorHandler
+	groups       map[*router.Route]*Group
+	urls         core.URLBuilder
+	validator    *core.Validator
+	encoders     *core.Encoders
+	keyring      *core.Keyring
+	logger       *core.Logger
+	autoHead     bool
+	autoOptions  bool
 	EngineCtx    *core.EngineContext
 	cache        *cache.Engine
 	pubsub       *pubsub.Engine
 	queue        *queue.Engine
-}
 
-type Group struct {
-	prefix     string
-	middleware []core.Handler
-	app        *App
+	config Config
+
+	lifecycleMu sync.Mutex
+	servers     []*fasthttp.Server
+	onShutdown  []func(context.Context) error
+	stopping    bool
+	stopped     chan struct{}
 }
 
-func New() *App {
-	return &App{
+// RouteConflictError is the error raised when a registration collides with
+// an existing route.
+type RouteConflictError = router.ConflictError
+
+// New creates an app. An optional Config tunes the server, router, logger
+// and engines; zero fields take their DefaultConfig value and an invalid
+// config panics.
+func New(config ...Config) *App {
+	cfg := DefaultConfig()
+	if len(config) > 0 {
+		cfg = config[0].withDefaults()
+		if err := cfg.Validate(); err != nil {
+			panic("invalid config: " + err.Error())
+		}
+	}
+
+	a := &App{
 		routes:       make([]*router.Route, 0, 8),
+		routeIndex:   make(map[string]*router.Route, 8),
+		groups:       make(map[*router.Route]*Group),
 		middleware:   make([]core.Handler, 0, 2),
 		singleRouter: router.NewSingleThreadedRouter(),
+		autoHead:     true,
+		autoOptions:  true,
 		EngineCtx:    core.NewEngineContext(),
+		validator:    core.NewValidator(),
+		encoders:     core.NewEncoders(),
+		logger:       core.Log,
+		config:       cfg,
+		stopped:      make(chan struct{}),
+	}
+	a.urls = appURLs{app: a}
+	if len(config) > 0 {
+		a.applyConfig()
 	}
+	return a
 }
 
 func (a *App) WithCache() *App {
@@ -76,17 +134,16 @@ func (a *App) WithCache() *App {
 	return a
 }
 
+// Use appends app middleware, run before every route. Each argument is a
+// core.Handler or a core.HandlerFunc, see core.ToHandler.
 func (a *App) Use(args ...any) *App {
-	for _, arg := range args {
-		if h, ok := arg.(core.Handler); ok {
-			a.middleware = append(a.middleware, h)
-		}
-	}
+	a.middleware = append(a.middleware, core.ToHandlers(args)...)
+	a.mwNames = append(a.mwNames, handlerNames(args)...)
 	return a
 }
 
 func loggerWrap(c *core.Context, _ []core.Handler) {
-	if core.DisableLogger {
+	if core.DisableLogger || c.Logger().Disabled {
 		return
 	}
 
@@ -134,117 +191,435 @@ func loggerMiddleware(c *core.Context) {
 	loggerWrap(c, nil)
 }
 
-func (a *App) Route(path string) *router.Route {
-	for _, r := range a.routes {
-		if r.Path == path {
-			return r
+// NotFound replaces the handler used when no route matches the request path.
+func (a *App) NotFound(h core.Handler) *App {
+	if h == nil {
+		panic("not found handler cannot be nil")
+	}
+	a.notFound = h
+	a.singleRouter.SetNotFoundHandler(h)
+	return a
+}
+
+// MethodNotAllowed replaces the handler used when the path is registered
+// under other methods only. The Allow header is already set when it runs.
+func (a *App) MethodNotAllowed(h core.Handler) *App {
+	if h == nil {
+		panic("method not allowed handler cannot be nil")
+	}
+	a.singleRouter.SetMethodNotAllowedHandler(h)
+	return a
+}
+
+// URL builds the path of a named route from key/value pairs, e.g.
+// app.URL("user.posts", "id", "42", "post", "7").
+func (a *App) URL(name string, params ...string) (string, error) {
+	if len(params)%2 != 0 {
+		return "", fmt.Errorf("route %q: params must be key/value pairs", name)
+	}
+	values := make(map[string]string, len(params)/2)
+	for i := 0; i < len(params); i += 2 {
+		values[params[i]] = params[i+1]
+	}
+	return a.urlFor(name, values)
+}
+
+// urlFor builds a named route's path, searching the default router first
+// and then the host routers.
+func (a *App) urlFor(name string, params map[string]string) (string, error) {
+	for _, rt := range a.routers() {
+		if rt.NamedRoute(name) != nil {
+			return rt.URL(name, params)
 		}
 	}
-	return nil
+	return a.singleRouter.URL(name, params)
+}
+
+// appURLs lets core.Context build URLs through the app's routers.
+type appURLs struct{ app *App }
+
+func (u appURLs) URL(name string, params map[string]string) (string, error) {
+	return u.app.urlFor(name, params)
 }
 
+// Route returns the first route registered with exactly this path.
+func (a *App) Route(path string) *router.Route {
+	return a.routeIndex[path]
+}
+
+// RunServer serves on addr until SIGINT or SIGTERM, then shuts down
+// gracefully. It is Listen with a background context.
 func (a *App) RunServer(addr string) error {
-	runtime.GOMAXPROCS(runtime.NumCPU())
-	fmt.Println(Banner(addr))
-	return fasthttp.ListenAndServe(addr, a.Handler())
+	return a.Listen(context.Background(), addr)
 }
 
 func (a *App) Handler() fasthttp.RequestHandler {
 	return func(ctx *fasthttp.RequestCtx) {
 		method := string(ctx.Method())
 		path := string(ctx.Path())
-		handler, route, params := a.singleRouter.Find(method, path)
+		params := router.AcquireParams()
+
+		rt := a.singleRouter
+		if len(a.hosts) > 0 {
+			rt = a.matchHost(ctx, params)
+		}
+		hostParams := len(*params)
+		handler, route, found := a.resolve(ctx, rt, method, path, params)
+		if !found && rt != a.singleRouter {
+			*params = (*params)[:hostParams]
+			handler, route, found = a.resolve(ctx, a.singleRouter, method, path, params)
+		}
+		if !found {
+			if h := a.scopedNotFound(rt, path); h != nil {
+				handler = h
+			}
+		}
 
-		allHandlers := make([]core.Handler, 0, len(a.middleware)+1)
+		size := len(a.middleware) + 3
+		if route != nil {
+			size += len(route.Handlers)
+		}
+		allHandlers := make([]core.Handler, 0, size)
 		allHandlers = append(allHandlers, a.middleware...)
 		allHandlers = append(allHandlers, handler)
+		if route != nil {
+			if a.cacheable(route) {
+				allHandlers = append(allHandlers, a.responseCache(route))
+			}
+			allHandlers = append(allHandlers, route.Handlers...)
+		}
 		allHandlers = append(allHandlers, loggerMiddleware)
 
 		c := core.NewContext(ctx, a.cache, allHandlers)
 
-		if len(params) > 0 {
-			for k, v := range params {
-				c.SetParam(k, v)
-			}
+		for _, p := range *params {
+			c.SetParam(p.Key, p.Value)
+		}
+		router.ReleaseParams(params)
+
+		a.setupContext(c)
+		a.run(c, route)
+		core.ReleaseContext(c)
+	}
+}
+
+// setupContext gives c the app's engines and per-app settings.
+func (a *App) setupContext(c *core.Context) {
+	if a.pubsub != nil {
+		c.SetPubsub(a.pubsub)
+	}
+	if a.queue != nil {
+		c.SetQueue(a.queue)
+	}
+	c.SetURLBuilder(a.urls)
+	c.SetValidator(a.validator)
+	c.SetEncoders(a.encoders)
+	c.SetKeyring(a.keyring)
+	c.SetLogger(a.logger)
+}
+
+// resolve finds the handler for a request in rt, falling back to the GET
+// route for HEAD, an automatic OPTIONS answer, then 405 or 404. found is
+// false only when the path is unknown to rt.
+func (a *App) resolve(ctx *fasthttp.RequestCtx, rt *router.SingleThreadedRouter, method, path string, params *router.Params) (core.Handler, *router.Route, bool) {
+	config := a.singleRouter.Config()
+	if config.CleanPath {
+		raw := string(ctx.URI().PathOriginal())
+		if clean := router.CleanPath(raw); clean != raw {
+			return redirectHandler(ctx, method, clean), nil, true
 		}
+	}
+
+	handler, route := rt.Find(method, path, params)
+	if route != nil {
+		return handler, route, true
+	}
 
-		if a.pubsub != nil {
-			c.SetPubsub(a.pubsub)
+	if config.TrailingSlash != router.TrailingSlashStrict && path != "/" {
+		alt := path + "/"
+		if strings.HasSuffix(path, "/") {
+			alt = strings.TrimSuffix(path, "/")
 		}
-		if a.queue != nil {
-			c.SetQueue(a.queue)
+		if h, r := rt.Find(method, alt, params); r != nil {
+			if config.TrailingSlash == router.TrailingSlashMatch {
+				return h, r, true
+			}
+			// Redirect to the raw path so escapes such as %3F stay escaped.
+			// A trailing "%2F" is not a slash to trim.
+			raw := string(ctx.URI().PathOriginal())
+			target, ok := raw+"/", true
+			if strings.HasSuffix(path, "/") {
+				target, ok = strings.CutSuffix(raw, "/")
+			}
+			if ok {
+				return redirectHandler(ctx, method, target), nil, true
+			}
 		}
+	}
 
-		if route != nil {
-			a.routes = append(a.routes, route)
+	if method == fasthttp.MethodHead && a.autoHead {
+		if h, r := rt.Find(fasthttp.MethodGet, path, params); r != nil && !r.Options.NoAutoHead {
+			ctx.Response.SkipBody = true
+			return h, r, true
 		}
+	}
+
+	allowed, autoOptions := a.allowed(rt, method, path)
+	if len(allowed) == 0 {
+		return a.singleRouter.NotFoundHandler(), nil, false
+	}
+	ctx.Response.Header.Set("Allow", strings.Join(allowed, ", "))
 
-		c.Next()
-		defer core.ReleaseContext(c)
+	if method == fasthttp.MethodOptions && autoOptions {
+		return autoOptionsHandler, nil, true
 	}
+	return a.singleRouter.MethodNotAllowedHandler(), nil, true
 }
 
-func (a *App) Add(methods []string, path string, h core.Handler, hs ...core.Handler) *router.Route {
+// allowed lists the methods that can be used on path, including the HEAD and
+// OPTIONS methods answered automatically, and reports whether every route on
+// the path accepts the automatic OPTIONS answer.
+func (a *App) allowed(rt *router.SingleThreadedRouter, method, path string) ([]string, bool) {
+	methods := rt.Allowed(method, path)
+	if len(methods) == 0 {
+		return nil, false
+	}
+
+	autoOptions := a.autoOptions
+	hasHead, hasOptions := false, false
+	var ps router.Params
+	for _, m := range methods {
+		ps = ps[:0]
+		_, r := rt.Find(m, path, &ps)
+		if r != nil && r.Options.NoAutoOptions {
+			autoOptions = false
+		}
+		switch m {
+		case fasthttp.MethodGet:
+			if a.autoHead && r != nil && !r.Options.NoAutoHead {
+				hasHead = true
+			}
+		case fasthttp.MethodHead:
+			hasHead = true
+		case fasthttp.MethodOptions:
+			hasOptions = true
+		}
+	}
+
+	if hasHead && !slices.Contains(methods, fasthttp.MethodHead) {
+		methods = append(methods, fasthttp.MethodHead)
+	}
+	if autoOptions && !hasOptions {
+		methods = append(methods, fasthttp.MethodOptions)
+	}
+	sort.Strings(methods)
+	return methods, autoOptions
+}
+
+// redirectHandler sends the client to target, keeping the query string.
+// GET and HEAD get 301; other methods get 308 so the method and body survive.
+func redirectHandler(ctx *fasthttp.RequestCtx, method, target string) core.Handler {
+	if q := ctx.URI().QueryString(); len(q) > 0 {
+		target += "?" + string(q)
+	}
+	code := fasthttp.StatusPermanentRedirect
+	if method == fasthttp.MethodGet || method == fasthttp.MethodHead {
+		code = fasthttp.StatusMovedPermanently
+	}
+	return func(c *core.Context) {
+		c.Ctx.Response.Header.Set("Location", target)
+		c.Status(code)
+	}
+}
+
+func autoOptionsHandler(c *core.Context) {
+	c.Status(fasthttp.StatusNoContent)
+}
+
+// AutoHead toggles serving HEAD requests with the matching GET route.
+// The GET handler runs as usual and only the response body is dropped.
+func (a *App) AutoHead(enabled bool) *App {
+	a.autoHead = enabled
+	return a
+}
+
+// AutoOptions toggles answering OPTIONS requests with 204 and an Allow
+// header listing the methods registered for the path.
+func (a *App) AutoOptions(enabled bool) *App {
+	a.autoOptions = enabled
+	return a
+}
+
+// TrailingSlashPolicy decides how a path differing from a route only by a
+// trailing slash is handled.
+type TrailingSlashPolicy = router.TrailingSlashPolicy
+
+const (
+	TrailingSlashStrict   = router.TrailingSlashStrict
+	TrailingSlashRedirect = router.TrailingSlashRedirect
+	TrailingSlashMatch    = router.TrailingSlashMatch
+)
+
+// TrailingSlash sets how "/users/" is handled when only "/users" is
+// registered, and vice versa. The default keeps them distinct.
+func (a *App) TrailingSlash(policy TrailingSlashPolicy) *App {
+	a.configureRouters(func(config *router.RouterConfig) {
+		config.TrailingSlash = policy
+	})
+	return a
+}
+
+// CaseInsensitive toggles matching static path segments regardless of case.
+// Parameter values keep the case they were sent with.
+func (a *App) CaseInsensitive(enabled bool) *App {
+	a.configureRouters(func(config *router.RouterConfig) {
+		config.CaseInsensitive = enabled
+	})
+	return a
+}
+
+// CleanPath toggles redirecting requests with repeated slashes or "." and
+// ".." segments to the canonical path.
+func (a *App) CleanPath(enabled bool) *App {
+	a.configureRouters(func(config *router.RouterConfig) {
+		config.CleanPath = enabled
+	})
+	return a
+}
+
+// configureRouters applies fn to the config of the default and host routers.
+func (a *App) configureRouters(fn func(*router.RouterConfig)) {
+	for _, rt := range a.routers() {
+		config := rt.Config()
+		fn(&config)
+		rt.SetConfig(config)
+	}
+}
+
+func (a *App) routers() []*router.SingleThreadedRouter {
+	rts := make([]*router.SingleThreadedRouter, 0, len(a.hosts)+1)
+	rts = append(rts, a.singleRouter)
+	for _, h := range a.hosts {
+		rts = append(rts, h.router)
+	}
+	return rts
+}
+
+// StrictRouting makes conflicting registrations panic with a
+// *RouteConflictError instead of logging a warning. Conflicts are duplicate
+// or equivalent patterns for the same method, and parameters named
+// differently at the same position.
+func (a *App) StrictRouting(enabled bool) *App {
+	a.strictRouting = enabled
+	return a
+}
+
+// callerSource returns file:line of the first caller outside this package.
+func callerSource() string {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePath+".") {
+			return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+var packagePath = reflect.TypeOf(App{}).PkgPath()
+
+// Add registers a route for methods. Handlers are core.Handler or
+// core.HandlerFunc values, or plain funcs of either signature; errors
+// returned by a HandlerFunc go to the route's ErrorHandler.
+func (a *App) Add(methods []string, path string, h any, hs ...any) *router.Route {
+	return a.add(nil, methods, path, append([]any{h}, hs...))
+}
+
+// add registers a route on the router of g, or on the default router when g
+// is nil. The route's chain is g's middleware followed by the handlers; app
+// middleware is added per request so App.Use applies to every route.
+func (a *App) add(g *Group, methods []string, path string, hs []any) *router.Route {
+	return a.addChain(g, methods, path, core.ToHandlers(hs), handlerNames(hs), "")
+}
+
+// addChain is add with handlers already converted, and their names. An
+// empty source means the caller's file:line.
+func (a *App) addChain(g *Group, methods []string, path string, hs []core.Handler, names []string, source string) *router.Route {
 	if len(methods) == 0 {
 		panic("methods cannot be empty")
 	}
-	if h == nil {
+	if len(hs) == 0 || hs[0] == nil {
 		panic("primary handler cannot be nil")
 	}
 
-	handlers := make([]core.Handler, 0, len(hs)+1)
-	handlers = append(handlers, h)
-	handlers = append(handlers, hs...)
+	rt, host := a.singleRouter, ""
+	var chain []core.Handler
+	var chainNames []string
+	if g != nil {
+		rt, host = g.targetRouter(), g.host
+		path = g.fullPrefix() + path
+		chain, chainNames = g.chain()
+	}
+	chain = append(chain, hs...)
+	chainNames = append(chainNames, names...)
 
 	route := &router.Route{
 		Path:     path,
+		Host:     host,
 		Method:   methods[0],
-		Handlers: append(a.middleware, handlers...),
+		Methods:  append([]string(nil), methods...),
+		Handlers: chain,
+		Names:    chainNames,
 		Options:  router.RouteOptions{Disable: true},
 	}
-	a.routes = append(a.routes, route)
+	if g != nil {
+		for k, v := range g.meta() {
+			route.Meta(k, v)
+		}
+		a.groups[route] = g
+	}
+	route.Source = source
+	if route.Source == "" {
+		route.Source = callerSource()
+	}
 
 	for _, method := range methods {
-		finalHandlers := make([]core.Handler, 0, len(a.middleware)+len(handlers))
-		finalHandlers = append(finalHandlers, a.middleware...)
-		finalHandlers = append(finalHandlers, handlers...)
-
-		finalHandler := func(c *core.Context) {
-			defer func() {
-				if rec := recover(); rec != nil {
-					fmt.Printf("Panic in handler: %v\n", rec)
-					if c != nil && c.Ctx != nil {
-						c.Ctx.SetStatusCode(500)
-						c.Ctx.SetBodyString("Internal Server Error")
-					}
-				}
-			}()
-
-			for _, handler := range finalHandlers {
-				handler(c)
-				if c.Aborted() {
-					break
-				}
+		if err := rt.Check(method, path, route); err != nil {
+			if a.strictRouting {
+				panic(err)
 			}
-
+			a.logger.Warn("%v", err)
 		}
+	}
 
-		if strings.Contains(path, ":") {
-			a.singleRouter.AddParametric(method, path, finalHandler, route)
-		} else if strings.Contains(path, "*") {
-			prefix := strings.TrimSuffix(path, "*")
-			a.singleRouter.AddWildcard(method, prefix, finalHandler, route)
-		} else {
-			a.singleRouter.AddStatic(method, path, finalHandler, route)
-		}
+	a.routes = append(a.routes, route)
+	if _, exists := a.routeIndex[path]; !exists && host == "" {
+		a.routeIndex[path] = route
+	}
+
+	for _, method := range methods {
+		rt.Add(method, path, routeEntry, route)
 	}
 
 	return route
 }
 
-func (a *App) WithQueue() *App {
+// routeEntry is the handler stored in the router for a route. It runs the
+// route's chain, which Handler appends after it.
+func routeEntry(c *core.Context) {
+	c.Next()
+}
+
+// WithQueue enables the task queue engine, persisted to queue.json in the
+// data directory. Options apply only to the first call.
+func (a *App) WithQueue(opts ...StorageOptions) *App {
 	if a.queue == nil {
-		qe, err := queue.NewEngine()
+		file, options := a.storageFile("queue.json", opts)
+		qe, err := queue.NewEngineWithOptions(file, options)
 		if err != nil {
 			panic("failed to initialize queue: " + err.Error())
 		}
@@ -254,9 +629,12 @@ func (a *App) WithQueue() *App {
 	return a
 }
 
-func (a *App) WithPubsub() *App {
+// WithPubsub enables the pubsub engine, persisted to pubsub.json in the
+// data directory. Options apply only to the first call.
+func (a *App) WithPubsub(opts ...StorageOptions) *App {
 	if a.pubsub == nil {
-		ps, err := pubsub.NewEngine()
+		file, options := a.storageFile("pubsub.json", opts)
+		ps, err := pubsub.NewEngineWithOptions(file, options)
 		if err != nil {
 			panic("failed to initialize pubsub: " + err.Error())
 		}
@@ -266,92 +644,86 @@ func (a *App) WithPubsub() *App {
 	return a
 }
 
-func (a *App) WrapHandlers(hs []core.Handler) fasthttp.RequestHandler {
-	return func(ctx *fasthttp.RequestCtx) {
-		c := core.NewContext(ctx, a.cache, hs)
-		c.Next()
-		defer core.ReleaseContext(c)
+// WithCacheEngine makes store the app's cache, in place of the built-in
+// in-memory one. Call it instead of WithCache, which then does nothing.
+//
+//	app.WithCacheEngine(turbotest.NewCache())
+func (a *App) WithCacheEngine(store core.Cache) *App {
+	if store == nil {
+		panic("WithCacheEngine: nil cache engine")
 	}
+	a.cache = cache.NewEngineWith(store)
+	return a
 }
 
-func (a *App) Group(prefix string, middlewares ...core.Handler) *Group {
-	return &Group{
-		prefix:     prefix,
-		middleware: middlewares,
-		app:        a,
+// WithQueueEngine makes q the app's task queue, in place of the built-in
+// engine and its queue.json. Call it instead of WithQueue, which then does
+// nothing.
+func (a *App) WithQueueEngine(q core.Queue) *App {
+	if q == nil {
+		panic("WithQueueEngine: nil queue engine")
 	}
+	a.queue = queue.NewEngineWith(q)
+	a.EngineCtx.Queue = a.queue
+	return a
 }
 
-func (g *Group) Add(methods []string, path string, h core.Handler, hs ...core.Handler) *router.Route {
-	fullPath := g.prefix + path
-	allHandlers := make([]core.Handler, 0, len(g.middleware)+len(hs)+1)
-	allHandlers = append(allHandlers, g.middleware...)
-	allHandlers = append(allHandlers, h)
-	allHandlers = append(allHandlers, hs...)
+// WithPubsubEngine makes p the app's event bus, in place of the built-in
+// engine and its pubsub.json. Call it instead of WithPubsub, which then does
+// nothing.
+func (a *App) WithPubsubEngine(p core.Pubsub) *App {
+	if p == nil {
+		panic("WithPubsubEngine: nil pubsub engine")
+	}
+	a.pubsub = pubsub.NewEngineWith(p)
+	a.EngineCtx.Pubsub = a.pubsub
+	return a
+}
 
-	return g.app.Add(methods, fullPath, allHandlers[0], allHandlers[1:]...)
+// WrapHandlers returns a fasthttp handler running hs outside the router,
+// with the app's engines and settings. Errors and panics in hs are written
+// by the app's ErrorHandler, else DefaultErrorHandler.
+func (a *App) WrapHandlers(hs []core.Handler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		c := core.NewContext(ctx, a.cache, hs)
+		a.setupContext(c)
+		a.run(c, nil)
+		core.ReleaseContext(c)
+	}
 }
 
 // HTTP method shortcuts
-func (a *App) Get(path string, handler core.Handler, handlers ...core.Handler) *router.Route {
+func (a *App) Get(path string, handler any, handlers ...any) *router.Route {
 	return a.Add([]string{"GET"}, path, handler, handlers...)
 }
 
-func (a *App) Post(path string, handler core.Handler, handlers ...core.Handler) *router.Route {
+func (a *App) Post(path string, handler any, handlers ...any) *router.Route {
 	return a.Add([]string{"POST"}, path, handler, handlers...)
 }
 
-func (a *App) Put(path string, handler core.Handler, handlers ...core.Handler) *router.Route {
+func (a *App) Put(path string, handler any, handlers ...any) *router.Route {
 	return a.Add([]string{"PUT"}, path, handler, handlers...)
 }
 
-func (a *App) Delete(path string, handler core.Handler, handlers ...core.Handler) *router.Route {
+func (a *App) Delete(path string, handler any, handlers ...any) *router.Route {
 	return a.Add([]string{"DELETE"}, path, handler, handlers...)
 }
 
-func (a *App) Patch(path string, handler core.Handler, handlers ...core.Handler) *router.Route {
+func (a *App) Patch(path string, handler any, handlers ...any) *router.Route {
 	return a.Add([]string{"PATCH"}, path, handler, handlers...)
 }
 
-func (a *App) Options(path string, handler core.Handler, handlers ...core.Handler) *router.Route {
+func (a *App) Options(path string, handler any, handlers ...any) *router.Route {
 	return a.Add([]string{"OPTIONS"}, path, handler, handlers...)
 }
 
-func (a *App) Head(path string, handler core.Handler, handlers ...core.Handler) *router.Route {
+func (a *App) Head(path string, handler any, handlers ...any) *router.Route {
 	return a.Add([]string{"HEAD"}, path, handler, handlers...)
 }
 
-func (a *App) All(path string, handler core.Handler, handlers ...core.Handler) *router.Route {
+func (a *App) All(path string, handler any, handlers ...any) *router.Route {
 	return a.Add([]string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS", "HEAD"}, path, handler, handlers...)
 }
-func (a *App) Connect(path string, h core.Handler, hs ...core.Handler) *router.Route {
+func (a *App) Connect(path string, h any, hs ...any) *router.Route {
 	return a.Add([]string{"CONNECT"}, path, h, hs...)
 }
-func (g *Group) Get(path string, h core.Handler, hs ...core.Handler) *router.Route {
-	return g.Add([]string{"GET"}, path, h, hs...)
-}
-
-func (g *Group) Post(path string, h core.Handler, hs ...core.Handler) *router.Route {
-	return g.Add([]string{"POST"}, path, h, hs...)
-}
-
-func (g *Group) Put(path string, h core.Handler, hs ...core.Handler) *router.Route {
-	return g.Add([]string{"PUT"}, path, h, hs...)
-}
-
-func (g *Group) Delete(path string, h core.Handler, hs ...core.Handler) *router.Route {
-	return g.Add([]string{"DELETE"}, path, h, hs...)
-}
-
-func (g *Group) Patch(path string, h core.Handler, hs ...core.Handler) *router.Route {
-	return g.Add([]string{"PATCH"}, path, h, hs...)
-}
-
-func (g *Group) Options(path string, h core.Handler, hs ...core.Handler) *router.Route {
-	return g.Add([]string{"OPTIONS"}, path, h, hs...)
-}
-
-func (g *Group) All(path string, h core.Handler, hs ...core.Handler) *router.Route {
-	methods := []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"}
-	return g.Add(methods, path, h, hs...)
-}
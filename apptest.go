@@ -0,0 +1,92 @@
+package TurboGo
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+// ===== TEST HARNESS =====
+
+// DefaultTestTimeout bounds App.Test when no timeout is given.
+const DefaultTestTimeout = time.Second
+
+// Test sends req to the app over an in-memory connection and returns the
+// response with its body already read. The request goes through the same
+// server as Listen, with the Config limits, middleware and error handling,
+// but no port is opened. The optional timeout defaults to
+// DefaultTestTimeout; zero or less waits for as long as the handler runs.
+//
+//	req := httptest.NewRequest("GET", "/users/1", nil)
+//	res, err := app.Test(req)
+func (a *App) Test(req *http.Request, timeout ...time.Duration) (*http.Response, error) {
+	return a.roundTrip(req.Method, req.Write, timeout)
+}
+
+// TestRaw is Test for a raw HTTP/1.x request, written to the connection
+// byte for byte, which allows sending requests net/http would refuse.
+//
+//	res, err := app.TestRaw("GET /users/1 HTTP/1.1\r\nHost: example.com\r\n\r\n")
+func (a *App) TestRaw(raw string, timeout ...time.Duration) (*http.Response, error) {
+	method, _, _ := strings.Cut(raw, " ")
+	return a.roundTrip(method, func(w io.Writer) error {
+		_, err := io.WriteString(w, raw)
+		return err
+	}, timeout)
+}
+
+func (a *App) roundTrip(method string, write func(io.Writer) error, timeout []time.Duration) (*http.Response, error) {
+	d := DefaultTestTimeout
+	if len(timeout) > 0 {
+		d = timeout[0]
+	}
+
+	ln := fasthttputil.NewInmemoryListener()
+	defer ln.Close()
+	srv := a.newServer()
+	srv.Logger = quietLogger{} // the caller sees the failure in the response
+	go srv.Serve(ln)
+
+	conn, err := ln.Dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if d > 0 {
+		conn.SetDeadline(time.Now().Add(d))
+	}
+
+	if err := write(conn); err != nil {
+		return nil, testError("write request", d, err)
+	}
+	res, err := http.ReadResponse(bufio.NewReader(conn), &http.Request{Method: method})
+	if err != nil {
+		return nil, testError("read response", d, err)
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, testError("read body", d, err)
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	return res, nil
+}
+
+func testError(step string, timeout time.Duration, err error) error {
+	var timeoutErr interface{ Timeout() bool }
+	if errors.As(err, &timeoutErr) && timeoutErr.Timeout() {
+		return fmt.Errorf("test: no response within %s", timeout)
+	}
+	return fmt.Errorf("test: %s: %w", step, err)
+}
+
+type quietLogger struct{}
+
+func (quietLogger) Printf(string, ...any) {}
@@ -0,0 +1,288 @@
+package TurboGo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ===== CONFIG =====
+
+// Config tunes an App. Pass it to New; zero fields take the value from
+// DefaultConfig. The config key of each field, used in YAML/TOML files and
+// (upper-cased, after a prefix) in environment variables, is in its tag.
+type Config struct {
+	// Server limits, passed to the underlying fasthttp.Server. Zero timeouts
+	// mean no limit.
+	ServerName         string        `config:"server_name"`
+	ReadTimeout        time.Duration `config:"read_timeout"`
+	WriteTimeout       time.Duration `config:"write_timeout"`
+	IdleTimeout        time.Duration `config:"idle_timeout"`
+	MaxRequestBodySize int           `config:"max_request_body_size"` // bytes
+	Concurrency        int           `config:"concurrency"`           // max simultaneous connections
+	ReadBufferSize     int           `config:"read_buffer_size"`      // also caps the request header size
+	WriteBufferSize    int           `config:"write_buffer_size"`
+	MaxConnsPerIP      int           `config:"max_conns_per_ip"`
+	MaxRequestsPerConn int           `config:"max_requests_per_conn"`
+	DisableKeepalive   bool          `config:"disable_keepalive"`
+	ReduceMemoryUsage  bool          `config:"reduce_memory_usage"`
+	ShutdownTimeout    time.Duration `config:"shutdown_timeout"`
+
+	// Prefork makes Listen serve from PreforkChildren processes sharing the
+	// port with SO_REUSEPORT; 0 children means one per CPU. See App.Prefork.
+	Prefork         bool `config:"prefork"`
+	PreforkChildren int  `config:"prefork_children"`
+
+	// Router policies, see the App methods of the same name.
+	StrictRouting      bool                `config:"strict_routing"`
+	CaseInsensitive    bool                `config:"case_insensitive"`
+	CleanPath          bool                `config:"clean_path"`
+	TrailingSlash      TrailingSlashPolicy `config:"trailing_slash"` // "strict", "redirect" or "match"
+	DisableAutoHead    bool                `config:"disable_auto_head"`
+	DisableAutoOptions bool                `config:"disable_auto_options"`
+	ShowRoutes         bool                `config:"show_routes"`
+
+	// Logging.
+	DisableLogger bool   `config:"disable_logger"`
+	LogLevel      string `config:"log_level"` // debug, info, warn or error
+	DisableBanner bool   `config:"disable_banner"`
+
+	// DataDir holds the queue and pubsub persistence files.
+	DataDir string `config:"data_dir"`
+}
+
+// DefaultConfig returns the configuration used by New without arguments.
+func DefaultConfig() Config {
+	return Config{
+		MaxRequestBodySize: 4 * 1024 * 1024,
+		Concurrency:        256 * 1024,
+		ReadBufferSize:     4096,
+		WriteBufferSize:    4096,
+		ShutdownTimeout:    10 * time.Second,
+		LogLevel:           "debug",
+		DataDir:            "data",
+	}
+}
+
+// withDefaults fills zero fields from DefaultConfig.
+func (c Config) withDefaults() Config {
+	def := DefaultConfig()
+	if c.MaxRequestBodySize == 0 {
+		c.MaxRequestBodySize = def.MaxRequestBodySize
+	}
+	if c.Concurrency == 0 {
+		c.Concurrency = def.Concurrency
+	}
+	if c.ReadBufferSize == 0 {
+		c.ReadBufferSize = def.ReadBufferSize
+	}
+	if c.WriteBufferSize == 0 {
+		c.WriteBufferSize = def.WriteBufferSize
+	}
+	if c.ShutdownTimeout == 0 {
+		c.ShutdownTimeout = def.ShutdownTimeout
+	}
+	if c.LogLevel == "" {
+		c.LogLevel = def.LogLevel
+	}
+	if c.DataDir == "" {
+		c.DataDir = def.DataDir
+	}
+	return c
+}
+
+// Validate reports every invalid field of c.
+func (c Config) Validate() error {
+	var errs []error
+	v := reflect.ValueOf(c)
+	for i := 0; i < v.NumField(); i++ {
+		key := v.Type().Field(i).Tag.Get("config")
+		switch f := v.Field(i); f.Kind() {
+		case reflect.Int, reflect.Int64:
+			if f.Int() < 0 {
+				errs = append(errs, fmt.Errorf("%s must not be negative", key))
+			}
+		}
+	}
+	if _, ok := logLevels[strings.ToLower(c.LogLevel)]; !ok {
+		errs = append(errs, fmt.Errorf("log_level: unknown level %q", c.LogLevel))
+	}
+	if c.TrailingSlash > TrailingSlashMatch {
+		errs = append(errs, fmt.Errorf("trailing_slash: unknown policy %d", c.TrailingSlash))
+	}
+	return errors.Join(errs...)
+}
+
+var logLevels = map[string]core.LogLevel{
+	"debug": core.DEBUG,
+	"info":  core.INFO,
+	"warn":  core.WARN,
+	"error": core.ERROR,
+}
+
+var trailingSlashPolicies = map[string]TrailingSlashPolicy{
+	"strict":   TrailingSlashStrict,
+	"redirect": TrailingSlashRedirect,
+	"match":    TrailingSlashMatch,
+}
+
+// Config returns the app's configuration.
+func (a *App) Config() Config {
+	return a.config
+}
+
+// applyConfig applies the router and logger settings of a.config.
+func (a *App) applyConfig() {
+	c := a.config
+	a.StrictRouting(c.StrictRouting).
+		CaseInsensitive(c.CaseInsensitive).
+		CleanPath(c.CleanPath).
+		TrailingSlash(c.TrailingSlash).
+		AutoHead(!c.DisableAutoHead).
+		AutoOptions(!c.DisableAutoOptions).
+		ShowRoutes(c.ShowRoutes)
+
+	// The app gets its own logger so a quiet config does not silence other
+	// apps in the process through core.Log.
+	a.logger = &core.Logger{
+		Level:    logLevels[strings.ToLower(c.LogLevel)],
+		Disabled: c.DisableLogger,
+	}
+}
+
+// Logger returns the logger of a: core.Log, unless a was created with a
+// Config, whose LogLevel and DisableLogger it then follows.
+func (a *App) Logger() *core.Logger {
+	return a.logger
+}
+
+// LoadConfig builds a Config from DefaultConfig, the YAML (.yaml, .yml) or
+// TOML (.toml) file at path if path is not empty, then TURBOGO_* environment
+// variables, and validates the result.
+//
+//	# turbogo.yaml
+//	read_timeout: 5s
+//	max_request_body_size: 1048576
+//	trailing_slash: redirect
+//
+//	TURBOGO_LOG_LEVEL=warn ./app
+func LoadConfig(path string) (Config, error) {
+	c := DefaultConfig()
+	if path != "" {
+		if err := c.loadFile(path); err != nil {
+			return c, err
+		}
+	}
+	if err := c.ApplyEnv("TURBOGO_"); err != nil {
+		return c, err
+	}
+	return c, c.Validate()
+}
+
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]any{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.NewDecoder(bytes.NewReader(data)).Decode(&values)
+	default:
+		return fmt.Errorf("config %s: unsupported format %q", path, ext)
+	}
+	if err != nil {
+		return fmt.Errorf("config %s: %w", path, err)
+	}
+
+	fields := c.fields()
+	for key, value := range values {
+		f, ok := fields[key]
+		if !ok {
+			return fmt.Errorf("config %s: unknown key %q", path, key)
+		}
+		if err := setField(f, fmt.Sprint(value)); err != nil {
+			return fmt.Errorf("config %s: %s: %w", path, key, err)
+		}
+	}
+	return nil
+}
+
+// ApplyEnv overrides fields with environment variables named prefix plus
+// the upper-cased config key, e.g. TURBOGO_READ_TIMEOUT=5s.
+func (c *Config) ApplyEnv(prefix string) error {
+	for key, f := range c.fields() {
+		name := prefix + strings.ToUpper(key)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := setField(f, value); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// fields maps config keys to the addressable fields of c.
+func (c *Config) fields() map[string]reflect.Value {
+	v := reflect.ValueOf(c).Elem()
+	fields := make(map[string]reflect.Value, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		fields[v.Type().Field(i).Tag.Get("config")] = v.Field(i)
+	}
+	return fields
+}
+
+var (
+	durationType      = reflect.TypeOf(time.Duration(0))
+	trailingSlashType = reflect.TypeOf(TrailingSlashStrict)
+)
+
+func setField(f reflect.Value, value string) error {
+	value = strings.TrimSpace(value)
+	switch {
+	case f.Type() == durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		f.SetInt(int64(d))
+	case f.Type() == trailingSlashType:
+		p, ok := trailingSlashPolicies[strings.ToLower(value)]
+		if !ok {
+			return fmt.Errorf("unknown trailing slash policy %q", value)
+		}
+		f.Set(reflect.ValueOf(p))
+	case f.Kind() == reflect.String:
+		f.SetString(value)
+	case f.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+	case f.Kind() == reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		f.SetInt(int64(n))
+	default:
+		return fmt.Errorf("unsupported field type %s", f.Type())
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package TurboGo
+
+import "github.com/Dziqha/TurboGo/core"
+
+// ===== COOKIES =====
+
+// CookieKeys sets the secrets of signed and encrypted cookies, newest
+// first. New cookies use the first secret and every secret is accepted when
+// reading, so keys rotate by prepending a new secret and dropping the
+// oldest once its cookies have expired:
+//
+//	app.CookieKeys(os.Getenv("COOKIE_KEY"), os.Getenv("COOKIE_KEY_PREVIOUS"))
+//
+// Empty secrets are skipped, so an unset previous key is harmless. It panics
+// when no secret is left or one is shorter than core.MinKeyLength bytes.
+func (a *App) CookieKeys(secrets ...string) *App {
+	keys := make([]string, 0, len(secrets))
+	for _, s := range secrets {
+		if s != "" {
+			keys = append(keys, s)
+		}
+	}
+	keyring, err := core.NewKeyring(keys...)
+	if err != nil {
+		panic(err.Error())
+	}
+	a.keyring = keyring
+	return a
+}
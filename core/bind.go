@@ -0,0 +1,377 @@
+package core
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FieldError describes one request value that could not be bound or broke
+// a validation rule.
+type FieldError struct {
+	Field   string `json:"field"`  // the tag name, e.g. "page"
+	Source  string `json:"source"` // param, query, header, form or body
+	Value   string `json:"value,omitempty"`
+	Rule    string `json:"rule,omitempty"`  // the broken rule, e.g. "min"
+	Param   string `json:"param,omitempty"` // its parameter, e.g. "3"
+	Message string `json:"message"`
+}
+
+// BindError lists every field Bind could not fill. Bind returns it wrapped
+// in a 400 HTTPError whose Details are the fields.
+type BindError struct {
+	Fields []FieldError
+}
+
+func (e *BindError) Error() string {
+	parts := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		parts[i] = fmt.Sprintf("%s %s: %s", f.Source, f.Field, f.Message)
+	}
+	return "bind: " + strings.Join(parts, "; ")
+}
+
+// bindSources are the struct tags Bind reads, in the order they are applied.
+var bindSources = []string{"param", "query", "header", "form"}
+
+// Bind fills the struct dst points to from the request. The body is decoded
+// by Content-Type: JSON and XML into the whole struct with their usual tags,
+// URL-encoded and multipart forms into fields tagged `form`. Fields tagged
+// `param`, `query` or `header` are then set from the route parameters, the
+// query string and the request headers.
+//
+//	type ListUsers struct {
+//		Tenant string    `header:"X-Tenant"`
+//		Org    int       `param:"org"`
+//		Page   int       `query:"page"`
+//		Tags   []string  `query:"tag"`
+//		Since  time.Time `query:"since" layout:"2006-01-02"`
+//	}
+//
+// Values convert to strings, ints, uints, floats, bools, time.Duration,
+// time.Time (RFC 3339 unless a `layout` tag is set), encoding.TextUnmarshaler
+// types, pointers to those, and slices of those from repeated values. Form
+// fields of type *multipart.FileHeader or []*multipart.FileHeader receive
+// uploaded files. Embedded structs are bound as part of their parent.
+//
+// Values that do not convert are collected into a *BindError, returned in a
+// 400 HTTPError; an unsupported Content-Type gives a 415 HTTPError.
+func (c *Context) Bind(dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind: destination must be a pointer to a struct, got %T", dst)
+	}
+	v := rv.Elem()
+
+	var fieldErrs []FieldError
+	form, err := c.bindBody(dst, &fieldErrs)
+	if err != nil {
+		return err
+	}
+
+	for _, source := range bindSources {
+		lookup := c.bindLookup(source, form)
+		if lookup == nil {
+			continue
+		}
+		for _, f := range structFields(v.Type()) {
+			key := f.tags[source]
+			if key == "" {
+				continue
+			}
+			field := v.FieldByIndex(f.index)
+			if source == "form" && form != nil && bindFiles(field, form.File[key]) {
+				continue
+			}
+			values := lookup(key)
+			if len(values) == 0 {
+				continue
+			}
+			if err := setField(field, values, f.layout); err != nil {
+				fieldErrs = append(fieldErrs, FieldError{Field: key, Source: source, Value: values[0], Message: err.Error()})
+			}
+		}
+	}
+
+	if len(fieldErrs) > 0 {
+		return NewHTTPError(400, "invalid request").
+			WithCode("invalid_request").
+			WithDetails(fieldErrs).
+			Wrap(&BindError{Fields: fieldErrs})
+	}
+	return nil
+}
+
+// bindBody decodes the request body into dst. Form bodies are returned for
+// the `form` tags instead; the multipart form is nil for URL-encoded ones.
+func (c *Context) bindBody(dst any, fieldErrs *[]FieldError) (*multipart.Form, error) {
+	body := c.Ctx.PostBody()
+	contentType := string(c.Ctx.Request.Header.ContentType())
+	if len(body) == 0 && contentType == "" {
+		return nil, nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		if len(bytes.TrimSpace(body)) == 0 {
+			return nil, nil
+		}
+		err := json.Unmarshal(body, dst)
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			*fieldErrs = append(*fieldErrs, FieldError{
+				Field:   typeErr.Field,
+				Source:  "body",
+				Message: "must be " + kindName(typeErr.Type),
+			})
+			return nil, nil
+		}
+		if err != nil {
+			return nil, NewHTTPError(400, "malformed JSON body").WithCode("malformed_body").Wrap(err)
+		}
+	case mediaType == "application/xml" || mediaType == "text/xml":
+		if len(bytes.TrimSpace(body)) == 0 {
+			return nil, nil
+		}
+		if err := xml.Unmarshal(body, dst); err != nil {
+			return nil, NewHTTPError(400, "malformed XML body").WithCode("malformed_body").Wrap(err)
+		}
+	case mediaType == "application/x-www-form-urlencoded":
+		return nil, nil
+	case mediaType == "multipart/form-data":
+		form, err := c.Ctx.MultipartForm()
+		if err != nil {
+			return nil, NewHTTPError(400, "malformed multipart body").WithCode("malformed_body").Wrap(err)
+		}
+		return form, nil
+	default:
+		if len(body) > 0 {
+			return nil, NewHTTPError(415, fmt.Sprintf("cannot bind a %q body", mediaType)).WithCode("unsupported_media_type")
+		}
+	}
+	return nil, nil
+}
+
+// bindLookup returns the value getter of a tag source, or nil when the
+// request has nothing for it.
+func (c *Context) bindLookup(source string, form *multipart.Form) func(string) []string {
+	switch source {
+	case "param":
+		return func(key string) []string {
+			if v := c.Param(key); v != "" {
+				return []string{v}
+			}
+			return nil
+		}
+	case "query":
+		return func(key string) []string { return byteStrings(c.Ctx.QueryArgs().PeekMulti(key)) }
+	case "header":
+		return func(key string) []string { return byteStrings(c.Ctx.Request.Header.PeekAll(key)) }
+	case "form":
+		if form != nil {
+			return func(key string) []string { return form.Value[key] }
+		}
+		if bytes.HasPrefix(c.Ctx.Request.Header.ContentType(), []byte("application/x-www-form-urlencoded")) {
+			return func(key string) []string { return byteStrings(c.Ctx.PostArgs().PeekMulti(key)) }
+		}
+	}
+	return nil
+}
+
+func byteStrings(values [][]byte) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = string(v)
+	}
+	return out
+}
+
+var fileHeaderType = reflect.TypeOf((*multipart.FileHeader)(nil))
+
+// bindFiles sets a file field and reports whether field is one.
+func bindFiles(field reflect.Value, files []*multipart.FileHeader) bool {
+	switch {
+	case field.Type() == fileHeaderType:
+		if len(files) > 0 {
+			field.Set(reflect.ValueOf(files[0]))
+		}
+		return true
+	case field.Kind() == reflect.Slice && field.Type().Elem() == fileHeaderType:
+		if len(files) > 0 {
+			field.Set(reflect.ValueOf(files))
+		}
+		return true
+	}
+	return false
+}
+
+// boundField is a struct field with at least one binding tag.
+type boundField struct {
+	index  []int
+	tags   map[string]string // source -> key
+	layout string
+}
+
+var fieldCache sync.Map // reflect.Type -> []boundField
+
+// structFields returns the tagged fields of t, including those of embedded
+// structs.
+func structFields(t reflect.Type) []boundField {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]boundField)
+	}
+	var fields []boundField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			for _, f := range structFields(sf.Type) {
+				f.index = append([]int{i}, f.index...)
+				fields = append(fields, f)
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		f := boundField{index: []int{i}, layout: sf.Tag.Get("layout")}
+		for _, source := range bindSources {
+			if key := sf.Tag.Get(source); key != "" && key != "-" {
+				if f.tags == nil {
+					f.tags = make(map[string]string)
+				}
+				f.tags[source] = key
+			}
+		}
+		if f.tags != nil {
+			fields = append(fields, f)
+		}
+	}
+	fieldCache.Store(t, fields)
+	return fields
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// setField converts values into field: the first value for scalars, all of
+// them for slices.
+func setField(field reflect.Value, values []string, layout string) error {
+	t := field.Type()
+	switch {
+	case t.Kind() == reflect.Pointer:
+		elem := reflect.New(t.Elem())
+		if err := setField(elem.Elem(), values, layout); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 && !reflect.PointerTo(t).Implements(textUnmarshalerType):
+		slice := reflect.MakeSlice(t, len(values), len(values))
+		for i, v := range values {
+			if err := setField(slice.Index(i), []string{v}, layout); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+	return setScalar(field, values[0], layout)
+}
+
+func setScalar(field reflect.Value, value, layout string) error {
+	t := field.Type()
+	switch {
+	case t == timeType && layout != "":
+		tm, err := time.Parse(layout, value)
+		if err != nil {
+			return fmt.Errorf("must be a time like %s", layout)
+		}
+		field.Set(reflect.ValueOf(tm))
+		return nil
+	case t == durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return errors.New("must be a duration such as 1m30s")
+		}
+		field.SetInt(int64(d))
+		return nil
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		if err := field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)); err != nil {
+			if t == timeType {
+				return fmt.Errorf("must be a time like %s", time.RFC3339)
+			}
+			return err
+		}
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.New("must be a boolean")
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, t.Bits())
+		if err != nil {
+			return errors.New("must be " + kindName(t))
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, t.Bits())
+		if err != nil {
+			return errors.New("must be " + kindName(t))
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, t.Bits())
+		if err != nil {
+			return errors.New("must be " + kindName(t))
+		}
+		field.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", t)
+	}
+	return nil
+}
+
+// kindName describes the values a type accepts, for error messages.
+func kindName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "an integer"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "a non-negative integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	}
+	return "a " + t.String()
+}
@@ -1,7 +1,11 @@
 package core
 
 import (
+	"crypto/x509"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -10,21 +14,39 @@ import (
 	"github.com/Dziqha/TurboGo/internal/pubsub"
 	"github.com/Dziqha/TurboGo/internal/queue"
 
+	"github.com/google/uuid"
 	"github.com/valyala/fasthttp"
 )
 
 type Handler func(*Context)
+
+// ErrorHandler writes the response for an error raised while handling a
+// request: one returned by a HandlerFunc, recorded with SetError, or a
+// recovered panic (*PanicError).
+type ErrorHandler func(*Context, error)
+
+// URLBuilder builds the path of a named route.
+type URLBuilder interface {
+	URL(name string, params map[string]string) (string, error)
+}
+
 type Context struct {
-	Ctx      *fasthttp.RequestCtx
-	Cache    *cache.Engine
-	Pubsub   *pubsub.Engine
-	Queue    *queue.Engine
-	index    int
-	handlers []Handler
-	aborted  bool
-	values   map[string]any // untuk menyimpan session atau data lainnya
-	Writer   *strings.Builder
-	params   map[string]string // untuk route parameters
+	Ctx       *fasthttp.RequestCtx
+	Cache     *cache.Engine
+	Pubsub    *pubsub.Engine
+	Queue     *queue.Engine
+	index     int
+	handlers  []Handler
+	aborted   bool
+	err       error
+	values    map[string]any // untuk menyimpan session atau data lainnya
+	Writer    *strings.Builder
+	params    map[string]string // untuk route parameters
+	urls      URLBuilder
+	validator *Validator
+	encoders  *Encoders
+	keyring   *Keyring
+	logger    *Logger
 }
 
 type EngineContext struct {
@@ -55,6 +77,12 @@ func NewContext(ctx *fasthttp.RequestCtx, cache *cache.Engine, handlers []Handle
 	c.index = -1 // agar Next() mulai dari index 0
 	c.handlers = handlers
 	c.aborted = false
+	c.err = nil
+	c.urls = nil
+	c.validator = nil
+	c.encoders = nil
+	c.keyring = nil
+	c.logger = nil
 
 	if c.params == nil {
 		c.params = make(map[string]string)
@@ -90,7 +118,13 @@ func ReleaseContext(c *Context) {
 	c.Queue = nil
 	c.handlers = nil
 	c.aborted = false
+	c.err = nil
 	c.index = -1
+	c.urls = nil
+	c.validator = nil
+	c.encoders = nil
+	c.keyring = nil
+	c.logger = nil
 
 	for k := range c.params {
 		delete(c.params, k)
@@ -117,18 +151,10 @@ func (c *Context) Next() {
 	}
 }
 
-// JSON writes a JSON response
+// JSON writes a JSON response with the app's JSON encoder. An encoding
+// failure is recorded with SetError.
 func (c *Context) JSON(status int, data any) {
-	c.Ctx.SetStatusCode(status)
-	c.Ctx.SetContentType("application/json")
-
-	res, err := json.Marshal(data)
-	if err != nil {
-		c.Ctx.SetStatusCode(fasthttp.StatusInternalServerError)
-		c.Ctx.SetBodyString(`{"error":"failed to marshal json"}`)
-		return
-	}
-	c.Ctx.SetBody(res)
+	c.Encode(status, MIMEJSON, data)
 }
 
 func (c *Context) Text(code int, msg string) {
@@ -152,6 +178,79 @@ func (c *Context) Param(key string) string {
 	return ""
 }
 
+func (c *Context) paramValue(key string) (string, error) {
+	val := c.Param(key)
+	if val == "" {
+		return "", fmt.Errorf("param %q is missing", key)
+	}
+	return val, nil
+}
+
+// ParamInt returns a route parameter parsed as an int.
+func (c *Context) ParamInt(key string) (int, error) {
+	val, err := c.paramValue(key)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, fmt.Errorf("param %q: %q is not an integer", key, val)
+	}
+	return n, nil
+}
+
+// ParamInt64 returns a route parameter parsed as an int64.
+func (c *Context) ParamInt64(key string) (int64, error) {
+	val, err := c.paramValue(key)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("param %q: %q is not an integer", key, val)
+	}
+	return n, nil
+}
+
+// ParamFloat returns a route parameter parsed as a float64.
+func (c *Context) ParamFloat(key string) (float64, error) {
+	val, err := c.paramValue(key)
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return 0, fmt.Errorf("param %q: %q is not a number", key, val)
+	}
+	return f, nil
+}
+
+// ParamBool returns a route parameter parsed as a bool.
+func (c *Context) ParamBool(key string) (bool, error) {
+	val, err := c.paramValue(key)
+	if err != nil {
+		return false, err
+	}
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		return false, fmt.Errorf("param %q: %q is not a boolean", key, val)
+	}
+	return b, nil
+}
+
+// ParamUUID returns a route parameter parsed as a UUID.
+func (c *Context) ParamUUID(key string) (uuid.UUID, error) {
+	val, err := c.paramValue(key)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	id, err := uuid.Parse(val)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("param %q: %q is not a valid UUID", key, val)
+	}
+	return id, nil
+}
+
 // Query returns a query parameter
 func (c *Context) Query(key string) string {
 	return string(c.Ctx.QueryArgs().Peek(key))
@@ -166,6 +265,21 @@ func (c *Context) Header(key string) string {
 	return string(c.Ctx.Request.Header.Peek(key))
 }
 
+// IsTLS reports whether the request came over HTTPS.
+func (c *Context) IsTLS() bool {
+	return c.Ctx.IsTLS()
+}
+
+// ClientCertificate returns the verified client certificate of an mTLS
+// connection, or nil when the client did not present a verified one.
+func (c *Context) ClientCertificate() *x509.Certificate {
+	state := c.Ctx.TLSConnectionState()
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return state.VerifiedChains[0][0]
+}
+
 func (c *Context) Abort() {
 	c.aborted = true
 }
@@ -174,6 +288,35 @@ func (c *Context) Aborted() bool {
 	return c.aborted
 }
 
+// SetError records err as the outcome of the request and aborts the chain.
+// The error handler writes the response once the chain has returned, so
+// middleware can still inspect or replace the error with Err and SetError.
+// SetError(nil) clears it.
+func (c *Context) SetError(err error) {
+	c.err = err
+	if err != nil {
+		c.aborted = true
+	}
+}
+
+// SetLogger sets the logger returned by Logger.
+func (c *Context) SetLogger(l *Logger) {
+	c.logger = l
+}
+
+// Logger returns the logger of the app serving the request, or Log.
+func (c *Context) Logger() *Logger {
+	if c.logger == nil {
+		return Log
+	}
+	return c.logger
+}
+
+// Err returns the error recorded with SetError.
+func (c *Context) Err() error {
+	return c.err
+}
+
 func (c *Context) Async(fn func()) {
 	concurrency.Async(fn)
 }
@@ -232,6 +375,18 @@ func (c *Context) SetParam(key, value string) {
 	}
 }
 
+func (c *Context) SetURLBuilder(u URLBuilder) {
+	c.urls = u
+}
+
+// URLFor builds the path of the route registered under name.
+func (c *Context) URLFor(name string, params map[string]string) (string, error) {
+	if c.urls == nil {
+		return "", errors.New("url builder is not set in Context")
+	}
+	return c.urls.URL(name, params)
+}
+
 func (c *Context) Status(code int) *Context {
 	c.Ctx.SetStatusCode(code)
 	return c
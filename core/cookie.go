@@ -0,0 +1,280 @@
+package core
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// SameSite is the SameSite attribute of a cookie.
+type SameSite string
+
+const (
+	SameSiteLax    SameSite = "Lax"
+	SameSiteStrict SameSite = "Strict"
+	SameSiteNone   SameSite = "None" // also makes the cookie Secure
+)
+
+// Cookie describes a cookie to set on the response.
+type Cookie struct {
+	Name   string
+	Value  string
+	Path   string // defaults to "/"
+	Domain string
+
+	// MaxAge is the lifetime in seconds; it wins over Expires. Both unset
+	// make a session cookie.
+	MaxAge  int
+	Expires time.Time
+
+	Secure   bool
+	HTTPOnly bool
+	SameSite SameSite // defaults to SameSiteLax
+
+	// Partitioned keeps the cookie in a per-site jar when embedded in other
+	// sites (CHIPS). It implies Secure and Path "/".
+	Partitioned bool
+}
+
+var (
+	// ErrNoCookie is returned when the request has no cookie of that name.
+	ErrNoCookie = errors.New("cookie: not present")
+	// ErrInvalidCookie is returned for a signed or encrypted cookie that is
+	// malformed, tampered with, or made with a key no longer in the keyring.
+	ErrInvalidCookie = errors.New("cookie: invalid value")
+	// ErrNoKeyring is returned by signed and encrypted cookies when the app
+	// has no cookie keys.
+	ErrNoKeyring = errors.New("cookie: no keyring configured")
+)
+
+// Cookie returns the value of the request cookie name, or "".
+func (c *Context) Cookie(name string) string {
+	return string(c.Ctx.Request.Header.Cookie(name))
+}
+
+// SetCookie adds a Set-Cookie header for ck.
+//
+//	c.SetCookie(core.Cookie{
+//		Name:     "theme",
+//		Value:    "dark",
+//		MaxAge:   30 * 24 * 3600,
+//		Secure:   true,
+//		HTTPOnly: true,
+//	})
+func (c *Context) SetCookie(ck Cookie) {
+	cookie := fasthttp.AcquireCookie()
+	defer fasthttp.ReleaseCookie(cookie)
+
+	cookie.SetKey(ck.Name)
+	cookie.SetValue(ck.Value)
+	if ck.Path == "" {
+		ck.Path = "/"
+	}
+	cookie.SetPath(ck.Path)
+	cookie.SetDomain(ck.Domain)
+	cookie.SetMaxAge(ck.MaxAge)
+	if !ck.Expires.IsZero() {
+		cookie.SetExpire(ck.Expires)
+	}
+	cookie.SetSecure(ck.Secure)
+	cookie.SetHTTPOnly(ck.HTTPOnly)
+	switch ck.SameSite {
+	case SameSiteStrict:
+		cookie.SetSameSite(fasthttp.CookieSameSiteStrictMode)
+	case SameSiteNone:
+		cookie.SetSameSite(fasthttp.CookieSameSiteNoneMode)
+	default:
+		cookie.SetSameSite(fasthttp.CookieSameSiteLaxMode)
+	}
+	cookie.SetPartitioned(ck.Partitioned)
+	c.Ctx.Response.Header.SetCookie(cookie)
+}
+
+// ClearCookie tells the client to delete the cookie name at Path "/". A
+// cookie set with another Path or Domain is cleared by SetCookie with the
+// same Path and Domain and a negative MaxAge.
+func (c *Context) ClearCookie(name string) {
+	c.SetCookie(Cookie{Name: name, MaxAge: -1, Expires: fasthttp.CookieExpireDelete})
+}
+
+// SetKeyring sets the keys used by signed and encrypted cookies.
+func (c *Context) SetKeyring(k *Keyring) {
+	c.keyring = k
+}
+
+// SetSignedCookie sets ck with its value signed by the app's keyring. The
+// value stays readable by the client but cannot be changed without the
+// signature breaking.
+func (c *Context) SetSignedCookie(ck Cookie) error {
+	if c.keyring == nil {
+		return ErrNoKeyring
+	}
+	ck.Value = c.keyring.sign(ck.Name, ck.Value)
+	c.SetCookie(ck)
+	return nil
+}
+
+// SignedCookie returns the verified value of the signed cookie name. It
+// returns ErrNoCookie when the cookie is missing and ErrInvalidCookie when
+// the signature does not match any key.
+func (c *Context) SignedCookie(name string) (string, error) {
+	if c.keyring == nil {
+		return "", ErrNoKeyring
+	}
+	raw := c.Cookie(name)
+	if raw == "" {
+		return "", ErrNoCookie
+	}
+	return c.keyring.verify(name, raw)
+}
+
+// SetEncryptedCookie sets ck with its value encrypted and authenticated by
+// the app's keyring, so the client can neither read nor change it.
+func (c *Context) SetEncryptedCookie(ck Cookie) error {
+	if c.keyring == nil {
+		return ErrNoKeyring
+	}
+	value, err := c.keyring.encrypt(ck.Name, ck.Value)
+	if err != nil {
+		return err
+	}
+	ck.Value = value
+	c.SetCookie(ck)
+	return nil
+}
+
+// EncryptedCookie returns the decrypted value of the encrypted cookie name.
+// It returns ErrNoCookie when the cookie is missing and ErrInvalidCookie
+// when no key decrypts it.
+func (c *Context) EncryptedCookie(name string) (string, error) {
+	if c.keyring == nil {
+		return "", ErrNoKeyring
+	}
+	raw := c.Cookie(name)
+	if raw == "" {
+		return "", ErrNoCookie
+	}
+	return c.keyring.decrypt(name, raw)
+}
+
+// MinKeyLength is the shortest secret a Keyring accepts, in bytes.
+const MinKeyLength = 32
+
+// Keyring holds the secrets of signed and encrypted cookies. The first key
+// signs and encrypts; every key is tried when reading. To rotate, put the
+// new secret first and drop the old one once its cookies have expired.
+type Keyring struct {
+	keys []cookieKey
+}
+
+type cookieKey struct {
+	sign []byte
+	aead cipher.AEAD
+}
+
+var cookieEncoding = base64.RawURLEncoding
+
+// NewKeyring returns a keyring of secrets, newest first. Each secret must
+// be at least MinKeyLength bytes; separate signing and encryption keys are
+// derived from it.
+func NewKeyring(secrets ...string) (*Keyring, error) {
+	if len(secrets) == 0 {
+		return nil, errors.New("cookie: keyring needs at least one secret")
+	}
+	k := &Keyring{keys: make([]cookieKey, len(secrets))}
+	for i, secret := range secrets {
+		if len(secret) < MinKeyLength {
+			return nil, fmt.Errorf("cookie: secret %d is %d bytes, need at least %d", i, len(secret), MinKeyLength)
+		}
+		block, err := aes.NewCipher(deriveKey(secret, "encrypt"))
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		k.keys[i] = cookieKey{sign: deriveKey(secret, "sign"), aead: aead}
+	}
+	return k, nil
+}
+
+func deriveKey(secret, purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("turbogo cookie " + purpose))
+	return mac.Sum(nil)
+}
+
+// sign returns value and its MAC, bound to the cookie name so a value can
+// not be moved to another cookie.
+func (k *Keyring) sign(name, value string) string {
+	encoded := cookieEncoding.EncodeToString([]byte(value))
+	return encoded + "." + cookieEncoding.EncodeToString(cookieMAC(k.keys[0].sign, name, encoded))
+}
+
+func (k *Keyring) verify(name, raw string) (string, error) {
+	encoded, sig, ok := strings.Cut(raw, ".")
+	if !ok {
+		return "", ErrInvalidCookie
+	}
+	mac, err := cookieEncoding.DecodeString(sig)
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+	for _, key := range k.keys {
+		if hmac.Equal(mac, cookieMAC(key.sign, name, encoded)) {
+			value, err := cookieEncoding.DecodeString(encoded)
+			if err != nil {
+				return "", ErrInvalidCookie
+			}
+			return string(value), nil
+		}
+	}
+	return "", ErrInvalidCookie
+}
+
+func cookieMAC(key []byte, name, encoded string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(name))
+	mac.Write([]byte{'|'})
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// encrypt seals value with AES-GCM, using the cookie name as additional
+// data.
+func (k *Keyring) encrypt(name, value string) (string, error) {
+	aead := k.keys[0].aead
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(value)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(value), []byte(name))
+	return cookieEncoding.EncodeToString(sealed), nil
+}
+
+func (k *Keyring) decrypt(name, raw string) (string, error) {
+	sealed, err := cookieEncoding.DecodeString(raw)
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+	for _, key := range k.keys {
+		size := key.aead.NonceSize()
+		if len(sealed) < size {
+			return "", ErrInvalidCookie
+		}
+		if value, err := key.aead.Open(nil, sealed[:size], sealed[size:], []byte(name)); err == nil {
+			return string(value), nil
+		}
+	}
+	return "", ErrInvalidCookie
+}
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// Cache is a key-value store with expiry: the store behind
+// Context.Cache.Memory and route response caching. The built-in in-memory
+// cache implements it; App.WithCacheEngine installs another, such as a fake
+// in tests.
+type Cache interface {
+	Set(key string, value []byte, ttl time.Duration)
+	Get(key string) ([]byte, bool)
+	Delete(key string) bool
+	Exists(key string) bool
+	SetEx(key string, value []byte, seconds int)
+	SetNX(key string, value []byte, ttl time.Duration) bool
+	TTL(key string) time.Duration
+	Size() int
+	Range(fn func(key string, value []byte))
+	Close()
+}
+
+// Queue is a task queue that can replace the built-in engine through
+// App.WithQueueEngine. Context.Queue and EngineContext.Queue hand EnqueueAll
+// and RegisterWorkerAll to it; their Memory and Storage queues are then nil.
+type Queue interface {
+	EnqueueAll(queue string, task []byte) error
+	RegisterWorkerAll(queue string, handler func([]byte) error)
+	// Shutdown lets running tasks finish and releases the queue. It is
+	// called by App.Shutdown.
+	Shutdown(ctx context.Context) error
+}
+
+// Pubsub is an event bus that can replace the built-in engine through
+// App.WithPubsubEngine. Context.Pubsub and EngineContext.Pubsub hand
+// PublishAll and SubscribeAll to it; their Memory and Storage buses are then
+// nil.
+type Pubsub interface {
+	PublishAll(topic string, data []byte) error
+	SubscribeAll(topic string) <-chan []byte
+	// Close ends every subscription. It is called by App.Shutdown.
+	Close()
+}
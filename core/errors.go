@@ -0,0 +1,130 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// HandlerFunc is a handler that returns its error instead of writing the
+// error response itself. The route's ErrorHandler renders the error.
+//
+//	app.Get("/users/:id", func(c *core.Context) error {
+//		user, err := users.Find(c.Param("id"))
+//		if err != nil {
+//			return core.NewHTTPError(404, "user not found").WithCode("user_not_found")
+//		}
+//		c.JSON(200, user)
+//		return nil
+//	})
+type HandlerFunc func(*Context) error
+
+// ToHandler converts a Handler, a HandlerFunc or a func of either signature
+// to a Handler. Errors returned by a HandlerFunc are recorded with SetError.
+// It panics on any other type.
+func ToHandler(h any) Handler {
+	switch fn := h.(type) {
+	case nil:
+		return nil
+	case Handler:
+		return fn
+	case func(*Context):
+		return fn
+	case HandlerFunc:
+		return fn.handler()
+	case func(*Context) error:
+		return HandlerFunc(fn).handler()
+	}
+	panic(fmt.Sprintf("handler must be func(*core.Context) or func(*core.Context) error, got %T", h))
+}
+
+// ToHandlers converts every element of hs with ToHandler.
+func ToHandlers(hs []any) []Handler {
+	handlers := make([]Handler, len(hs))
+	for i, h := range hs {
+		handlers[i] = ToHandler(h)
+	}
+	return handlers
+}
+
+func (fn HandlerFunc) handler() Handler {
+	if fn == nil {
+		return nil
+	}
+	return func(c *Context) {
+		if err := fn(c); err != nil {
+			c.SetError(err)
+		}
+	}
+}
+
+// HTTPError is an error that carries the response it should produce. The
+// default error handler renders Status, Code, Message and Details; Err is
+// the cause, kept for logs and errors.Is/As but never sent to the client.
+type HTTPError struct {
+	Status  int
+	Code    string // machine-readable, e.g. "user_not_found"
+	Message string // defaults to the status text
+	Details any
+	Err     error
+}
+
+// NewHTTPError returns an HTTPError with the given status and message. An
+// empty message means the status text.
+func NewHTTPError(status int, message string) *HTTPError {
+	return &HTTPError{Status: status, Message: message}
+}
+
+// WithCode sets the machine-readable error code.
+func (e *HTTPError) WithCode(code string) *HTTPError {
+	e.Code = code
+	return e
+}
+
+// WithDetails sets extra data for the client, such as invalid fields.
+func (e *HTTPError) WithDetails(details any) *HTTPError {
+	e.Details = details
+	return e
+}
+
+// Wrap sets the underlying cause.
+func (e *HTTPError) Wrap(err error) *HTTPError {
+	e.Err = err
+	return e
+}
+
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("%d %s", e.Status, e.Text())
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// Text returns the message, or the status text when it is empty.
+func (e *HTTPError) Text() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return http.StatusText(e.Status)
+}
+
+// PanicError is a panic recovered while handling a request, as passed to
+// the error handler.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
@@ -10,6 +10,8 @@ import (
 
 type LogLevel string
 
+// DisableLogger silences every Logger and the request log of every app in
+// the process. To quiet a single app, use its Config instead.
 var DisableLogger bool
 
 const (
@@ -20,13 +22,14 @@ const (
 )
 
 type Logger struct {
-	Level LogLevel
+	Level    LogLevel
+	Disabled bool // silences this logger only
 }
 
 var Log = &Logger{Level: DEBUG} // default
 
 func (l *Logger) output(level LogLevel, msg string, args ...any) {
-	if DisableLogger {
+	if DisableLogger || l.Disabled {
 		return
 	}
 	if !l.shouldLog(level) {
@@ -0,0 +1,302 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ugorji/go/codec"
+	"github.com/valyala/fasthttp"
+	"gopkg.in/yaml.v3"
+)
+
+// Encoder marshals a value for one media type. json.Marshal and
+// yaml.Marshal, or the Marshal of a faster JSON library, fit as they are.
+type Encoder func(v any) ([]byte, error)
+
+// Media types of the built-in encoders.
+const (
+	MIMEJSON    = "application/json"
+	MIMEXML     = "application/xml"
+	MIMEMsgPack = "application/msgpack"
+	MIMECBOR    = "application/cbor"
+	MIMEYAML    = "application/yaml"
+)
+
+// Encoders maps media types to encoders. Negotiate offers them in the order
+// they were first registered, so the first is used when the client accepts
+// anything.
+type Encoders struct {
+	types    []string
+	encoders map[string]Encoder
+}
+
+// defaultEncoders serves contexts that were not given encoders by an app.
+var defaultEncoders = NewEncoders()
+
+// NewEncoders returns the built-in encoders: JSON, XML, MessagePack, CBOR
+// and YAML, in that order.
+func NewEncoders() *Encoders {
+	e := &Encoders{encoders: make(map[string]Encoder, 5)}
+	e.Register(MIMEJSON, json.Marshal)
+	e.Register(MIMEXML, encodeXML)
+	e.Register(MIMEMsgPack, encodeCodec(&codec.MsgpackHandle{WriteExt: true}))
+	e.Register(MIMECBOR, encodeCodec(&codec.CborHandle{}))
+	e.Register(MIMEYAML, yaml.Marshal)
+	return e
+}
+
+// Register adds an encoder for mime, or replaces the current one in place.
+// It panics on an empty media type or a nil encoder.
+func (e *Encoders) Register(mime string, enc Encoder) *Encoders {
+	mime = strings.ToLower(strings.TrimSpace(mime))
+	if mime == "" || enc == nil {
+		panic(fmt.Sprintf("encoder for %q must have a media type and a function", mime))
+	}
+	if _, ok := e.encoders[mime]; !ok {
+		e.types = append(e.types, mime)
+	}
+	e.encoders[mime] = enc
+	return e
+}
+
+// Get returns the encoder of mime, or nil.
+func (e *Encoders) Get(mime string) Encoder {
+	return e.encoders[strings.ToLower(mime)]
+}
+
+// Types returns the registered media types in offer order.
+func (e *Encoders) Types() []string {
+	return append([]string(nil), e.types...)
+}
+
+// Merge registers the encoders of other whose media types e lacks.
+func (e *Encoders) Merge(other *Encoders) {
+	if other == nil || other == e {
+		return
+	}
+	for _, mime := range other.types {
+		if _, ok := e.encoders[mime]; !ok {
+			e.Register(mime, other.encoders[mime])
+		}
+	}
+}
+
+func encodeXML(v any) ([]byte, error) {
+	body, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+func encodeCodec(h codec.Handle) Encoder {
+	return func(v any) ([]byte, error) {
+		var out []byte
+		err := codec.NewEncoderBytes(&out, h).Encode(v)
+		return out, err
+	}
+}
+
+// SetEncoders sets the encoders used by JSON, Encode and Negotiate.
+func (c *Context) SetEncoders(e *Encoders) {
+	c.encoders = e
+}
+
+func (c *Context) encoder(mime string) Encoder {
+	if c.encoders != nil {
+		if enc := c.encoders.Get(mime); enc != nil {
+			return enc
+		}
+	}
+	return defaultEncoders.Get(mime)
+}
+
+// Encode writes data with the encoder registered for mime. A missing
+// encoder or an encoding failure is recorded with SetError, so the error
+// handler writes the response instead.
+func (c *Context) Encode(status int, mime string, data any) {
+	enc := c.encoder(mime)
+	if enc == nil {
+		c.SetError(fmt.Errorf("no encoder registered for %s", mime))
+		return
+	}
+	body, err := enc(data)
+	if err != nil {
+		c.SetError(fmt.Errorf("encode %s: %w", mime, err))
+		return
+	}
+	c.send(status, mime, body)
+}
+
+func (c *Context) send(status int, contentType string, body []byte) {
+	c.Ctx.SetStatusCode(status)
+	c.Ctx.SetContentType(contentType)
+	c.Ctx.SetBody(body)
+}
+
+// JSONPretty writes JSON indented with two spaces.
+func (c *Context) JSONPretty(status int, data any) {
+	body, err := c.encoder(MIMEJSON)(data)
+	if err != nil {
+		c.SetError(fmt.Errorf("encode %s: %w", MIMEJSON, err))
+		return
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, body, "", "  "); err != nil {
+		c.SetError(fmt.Errorf("encode %s: %w", MIMEJSON, err))
+		return
+	}
+	c.send(status, MIMEJSON, out.Bytes())
+}
+
+var jsonpCallback = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*(\.[A-Za-z_$][A-Za-z0-9_$]*)*$`)
+
+// JSONP writes data as a call to callback, for clients that load it with a
+// script tag. An empty callback writes plain JSON; one that is not a
+// JavaScript identifier path gives a 400 HTTPError.
+//
+//	c.JSONP(200, c.Query("callback"), data) // /**/cb({"id":1});
+func (c *Context) JSONP(status int, callback string, data any) {
+	if callback == "" {
+		c.JSON(status, data)
+		return
+	}
+	if !jsonpCallback.MatchString(callback) {
+		c.SetError(NewHTTPError(fasthttp.StatusBadRequest, "invalid JSONP callback").WithCode("invalid_callback"))
+		return
+	}
+	body, err := c.encoder(MIMEJSON)(data)
+	if err != nil {
+		c.SetError(fmt.Errorf("encode %s: %w", MIMEJSON, err))
+		return
+	}
+	out := make([]byte, 0, len(body)+len(callback)+8)
+	out = append(out, "/**/"...)
+	out = append(out, callback...)
+	out = append(out, '(')
+	out = append(out, body...)
+	out = append(out, ");"...)
+	c.Ctx.Response.Header.Set("X-Content-Type-Options", "nosniff")
+	c.send(status, "application/javascript; charset=utf-8", out)
+}
+
+// XML writes an XML response, starting with the XML declaration.
+func (c *Context) XML(status int, data any) {
+	c.Encode(status, MIMEXML, data)
+}
+
+// HTML writes an HTML response.
+func (c *Context) HTML(status int, html string) {
+	c.Ctx.SetStatusCode(status)
+	c.Ctx.SetContentType("text/html; charset=utf-8")
+	c.Ctx.SetBodyString(html)
+}
+
+// MsgPack writes a MessagePack response.
+func (c *Context) MsgPack(status int, data any) {
+	c.Encode(status, MIMEMsgPack, data)
+}
+
+// CBOR writes a CBOR response.
+func (c *Context) CBOR(status int, data any) {
+	c.Encode(status, MIMECBOR, data)
+}
+
+// YAML writes a YAML response.
+func (c *Context) YAML(status int, data any) {
+	c.Encode(status, MIMEYAML, data)
+}
+
+// Negotiate writes data in the registered format the Accept header prefers,
+// honouring q-values and wildcards; without an Accept header the first
+// registered format, JSON by default, is used. When nothing is acceptable
+// it records a 406 HTTPError whose Details list the available types.
+//
+//	app.Get("/users/:id", func(c *core.Context) error {
+//		c.Negotiate(200, user) // JSON, XML, YAML, ... as the client asks
+//		return nil
+//	})
+func (c *Context) Negotiate(status int, data any) {
+	types := defaultEncoders.types
+	if c.encoders != nil {
+		types = c.encoders.types
+	}
+	c.Ctx.Response.Header.Add("Vary", "Accept")
+	mime := c.Accepts(types...)
+	if mime == "" {
+		c.SetError(NewHTTPError(fasthttp.StatusNotAcceptable, "").
+			WithCode("not_acceptable").
+			WithDetails(map[string][]string{"available": types}))
+		return
+	}
+	c.Encode(status, mime, data)
+}
+
+// Accepts returns the offered media type the Accept header prefers, or ""
+// if none is acceptable. Without an Accept header it returns the first
+// offer.
+//
+//	switch c.Accepts("text/html", "application/json") {
+//	case "text/html":
+//		c.HTML(200, page)
+//	default:
+//		c.JSON(200, data)
+//	}
+func (c *Context) Accepts(offers ...string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+	accept := strings.TrimSpace(string(c.Ctx.Request.Header.Peek(fasthttp.HeaderAccept)))
+	if accept == "" {
+		return offers[0]
+	}
+
+	best, bestQ, bestSpecificity := "", 0.0, -1
+	for _, offer := range offers {
+		q, specificity := acceptQuality(accept, strings.ToLower(offer))
+		if q > bestQ || (q == bestQ && q > 0 && specificity > bestSpecificity) {
+			best, bestQ, bestSpecificity = offer, q, specificity
+		}
+	}
+	return best
+}
+
+// acceptQuality returns the q-value the most specific matching range of an
+// Accept header gives mime, and how specific that range is: 0 for */*, 1 for
+// type/*, 2 for an exact match.
+func acceptQuality(accept, mime string) (float64, int) {
+	mainType, _, _ := strings.Cut(mime, "/")
+	q, specificity := 0.0, -1
+	for _, part := range strings.Split(accept, ",") {
+		rng, params, _ := strings.Cut(part, ";")
+		rng = strings.ToLower(strings.TrimSpace(rng))
+
+		s := -1
+		switch {
+		case rng == mime:
+			s = 2
+		case rng == mainType+"/*":
+			s = 1
+		case rng == "*/*" || rng == "*":
+			s = 0
+		}
+		if s < specificity || s < 0 {
+			continue
+		}
+		rangeQ := 1.0
+		for _, param := range strings.Split(params, ";") {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					rangeQ = parsed
+				}
+			}
+		}
+		q, specificity = rangeQ, s
+	}
+	return q, specificity
+}
@@ -0,0 +1,651 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Rule reports whether value satisfies a validation rule. param is the text
+// after "=" in the tag, e.g. "3" for min=3. Pointers are dereferenced before
+// a rule runs; nil pointers are only checked by required.
+//
+//	slug := regexp.MustCompile(`^[a-z0-9-]+$`)
+//	func(v reflect.Value, _ string) bool { return slug.MatchString(v.String()) }
+type Rule func(value reflect.Value, param string) bool
+
+// ValidationError lists every field that broke a rule. Context.Validate
+// returns it wrapped in a 422 HTTPError whose Details are the fields.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		parts[i] = fmt.Sprintf("%s %s", f.Field, f.Message)
+	}
+	return "validate: " + strings.Join(parts, "; ")
+}
+
+// Validator checks structs against their `validate` tags. Register rules
+// and messages before serving; a Validator is safe for concurrent use once
+// it is set up.
+type Validator struct {
+	rules    map[string]Rule
+	messages map[string]map[string]string // language -> message key -> message
+}
+
+// defaultValidator serves contexts that were not given one by an app.
+var defaultValidator = NewValidator()
+
+// NewValidator returns a Validator with the built-in rules and their
+// English messages.
+func NewValidator() *Validator {
+	v := &Validator{
+		rules:    make(map[string]Rule, len(builtinRules)),
+		messages: map[string]map[string]string{"en": {}},
+	}
+	for name, rule := range builtinRules {
+		v.rules[name] = rule
+	}
+	for key, msg := range builtinMessages {
+		v.messages["en"][key] = msg
+	}
+	return v
+}
+
+// Register adds or replaces the rule name with an English message. It
+// panics on an empty or reserved name or a nil rule.
+func (v *Validator) Register(name string, rule Rule, message string) *Validator {
+	switch {
+	case name == "" || strings.ContainsAny(name, ",="):
+		panic(fmt.Sprintf("validate: invalid rule name %q", name))
+	case name == "required" || name == "omitempty" || name == "dive":
+		panic(fmt.Sprintf("validate: rule %q is reserved", name))
+	case rule == nil:
+		panic(fmt.Sprintf("validate: rule %q is nil", name))
+	}
+	v.rules[name] = rule
+	if message != "" {
+		v.messages["en"][name] = message
+	}
+	return v
+}
+
+// Messages adds or replaces the messages of a language, keyed by rule name.
+// A key may be suffixed with the kind of value it describes, ".string",
+// ".number" or ".items", to word lengths and sizes differently; "{param}"
+// is replaced with the rule parameter and "{field}" with the field name.
+//
+//	v.Messages("id", map[string]string{
+//		"required":   "wajib diisi",
+//		"min.string": "minimal {param} karakter",
+//	})
+func (v *Validator) Messages(lang string, messages map[string]string) *Validator {
+	lang = strings.ToLower(lang)
+	if v.messages[lang] == nil {
+		v.messages[lang] = make(map[string]string, len(messages))
+	}
+	for key, msg := range messages {
+		v.messages[lang][key] = msg
+	}
+	return v
+}
+
+// Merge copies the rules and messages of other that v does not define.
+func (v *Validator) Merge(other *Validator) {
+	if other == nil || other == v {
+		return
+	}
+	for name, rule := range other.rules {
+		if _, ok := v.rules[name]; !ok {
+			v.rules[name] = rule
+		}
+	}
+	for lang, msgs := range other.messages {
+		if v.messages[lang] == nil {
+			v.messages[lang] = make(map[string]string, len(msgs))
+		}
+		for key, msg := range msgs {
+			if _, ok := v.messages[lang][key]; !ok {
+				v.messages[lang][key] = msg
+			}
+		}
+	}
+}
+
+// Validate checks the struct s, or the struct it points to, and returns a
+// *ValidationError listing every invalid field. Messages use the first of
+// langs that has one, then English. Nested structs, and structs in slices
+// and maps, are checked too; the rules after "dive" apply to each element
+// of a slice or map:
+//
+//	type Order struct {
+//		Email string   `json:"email" validate:"required,email"`
+//		Tags  []string `json:"tags" validate:"max=5,dive,min=2"`
+//		Items []Item   `json:"items" validate:"required"`
+//	}
+//
+// Fields are named by their param, query or header tag, else their json or
+// form tag, else the Go name; nested ones as "items[0].sku". A rule that is
+// not registered panics.
+func (v *Validator) Validate(s any, langs ...string) error {
+	rv := reflect.ValueOf(s)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("validate: value must be a struct, got %T", s)
+	}
+
+	run := validation{v: v, langs: langs}
+	run.structFields(rv, "", "")
+	if len(run.errs) > 0 {
+		return &ValidationError{Fields: run.errs}
+	}
+	return nil
+}
+
+// Validate checks v with the app's rules, using the request's
+// Accept-Language for messages. Invalid fields give a 422 HTTPError with
+// code "validation_failed" whose Details are the fields.
+func (c *Context) Validate(v any) error {
+	validator := c.validator
+	if validator == nil {
+		validator = defaultValidator
+	}
+	err := validator.Validate(v, acceptLanguages(c.Header("Accept-Language"))...)
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return NewHTTPError(422, "validation failed").
+			WithCode("validation_failed").
+			WithDetails(validationErr.Fields).
+			Wrap(validationErr)
+	}
+	return err
+}
+
+// BindAndValidate runs Bind, then Validate.
+//
+//	app.Post("/users", func(c *core.Context) error {
+//		var in CreateUser
+//		if err := c.BindAndValidate(&in); err != nil {
+//			return err
+//		}
+//		...
+//	})
+func (c *Context) BindAndValidate(dst any) error {
+	if err := c.Bind(dst); err != nil {
+		return err
+	}
+	return c.Validate(dst)
+}
+
+// SetValidator sets the validator used by Validate.
+func (c *Context) SetValidator(v *Validator) {
+	c.validator = v
+}
+
+// validation is one Validate call.
+type validation struct {
+	v     *Validator
+	langs []string
+	errs  []FieldError
+}
+
+func (r *validation) structFields(sv reflect.Value, prefix, source string) {
+	for _, f := range validatedFields(sv.Type()) {
+		fv := sv.FieldByIndex(f.index)
+		name := joinField(prefix, f.name)
+		src := source
+		if src == "" {
+			src = f.source
+		}
+		if !r.check(fv, f.rules, name, src) {
+			continue
+		}
+		if f.dive != nil {
+			r.dive(fv, f.dive, name, src)
+			continue
+		}
+		r.nested(fv, name, src)
+	}
+}
+
+// check runs rules on value and records the first one it breaks.
+func (r *validation) check(value reflect.Value, rules []ruleSpec, name, source string) bool {
+	for _, spec := range rules {
+		switch spec.name {
+		case "omitempty":
+			if isEmpty(value) {
+				return true
+			}
+			continue
+		case "required":
+			if isEmpty(value) {
+				r.fail(spec, value, name, source)
+				return false
+			}
+			continue
+		}
+
+		rule, ok := r.v.rules[spec.name]
+		if !ok {
+			panic(fmt.Sprintf("validate: unknown rule %q on field %s", spec.name, name))
+		}
+		target := indirect(value)
+		if !target.IsValid() {
+			continue
+		}
+		if !rule(target, spec.param) {
+			r.fail(spec, target, name, source)
+			return false
+		}
+	}
+	return true
+}
+
+// dive checks every element of a slice, array or map against rules.
+func (r *validation) dive(value reflect.Value, rules []ruleSpec, name, source string) {
+	value = indirect(value)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			elemName := fmt.Sprintf("%s[%d]", name, i)
+			if r.check(value.Index(i), rules, elemName, source) {
+				r.nested(value.Index(i), elemName, source)
+			}
+		}
+	case reflect.Map:
+		for _, key := range sortedKeys(value) {
+			elemName := fmt.Sprintf("%s[%v]", name, key.Interface())
+			if r.check(value.MapIndex(key), rules, elemName, source) {
+				r.nested(value.MapIndex(key), elemName, source)
+			}
+		}
+	}
+}
+
+// nested validates the structs within value.
+func (r *validation) nested(value reflect.Value, name, source string) {
+	value = indirect(value)
+	if !value.IsValid() || !hasNested(value.Type()) {
+		return
+	}
+	switch value.Kind() {
+	case reflect.Struct:
+		r.structFields(value, name, source)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			r.nested(value.Index(i), fmt.Sprintf("%s[%d]", name, i), source)
+		}
+	case reflect.Map:
+		for _, key := range sortedKeys(value) {
+			r.nested(value.MapIndex(key), fmt.Sprintf("%s[%v]", name, key.Interface()), source)
+		}
+	}
+}
+
+func (r *validation) fail(spec ruleSpec, value reflect.Value, name, source string) {
+	r.errs = append(r.errs, FieldError{
+		Field:   name,
+		Source:  source,
+		Rule:    spec.name,
+		Param:   spec.param,
+		Message: r.v.message(spec, value, name, r.langs),
+	})
+}
+
+// message finds the text for a broken rule in the first language that has
+// one, trying the kind-specific key before the plain one.
+func (v *Validator) message(spec ruleSpec, value reflect.Value, name string, langs []string) string {
+	keys := []string{spec.name}
+	if kind := sizeKind(value); kind != "" {
+		keys = []string{spec.name + "." + kind, spec.name}
+	}
+	msg := "is invalid"
+	langs = append(langs[:len(langs):len(langs)], "en")
+lookup:
+	for _, lang := range langs {
+		msgs := v.messages[lang]
+		if msgs == nil {
+			if base, _, ok := strings.Cut(lang, "-"); ok {
+				msgs = v.messages[base]
+			}
+		}
+		for _, key := range keys {
+			if m, ok := msgs[key]; ok {
+				msg = m
+				break lookup
+			}
+		}
+	}
+	return strings.NewReplacer("{param}", spec.param, "{field}", name).Replace(msg)
+}
+
+// acceptLanguages returns the lower-cased language tags of an
+// Accept-Language header, most preferred first.
+func acceptLanguages(header string) []string {
+	if header == "" {
+		return nil
+	}
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q > 0 {
+			tags = append(tags, weighted{strings.ToLower(tag), q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+	langs := make([]string, len(tags))
+	for i, t := range tags {
+		langs[i] = t.tag
+	}
+	return langs
+}
+
+// ruleSpec is one entry of a `validate` tag.
+type ruleSpec struct {
+	name, param string
+}
+
+// validatedField is a struct field with rules, or one that may hold structs
+// with rules.
+type validatedField struct {
+	index  []int
+	name   string
+	source string
+	rules  []ruleSpec
+	dive   []ruleSpec // rules after "dive", nil without one
+}
+
+var validatedFieldCache sync.Map // reflect.Type -> []validatedField
+
+func validatedFields(t reflect.Type) []validatedField {
+	if cached, ok := validatedFieldCache.Load(t); ok {
+		return cached.([]validatedField)
+	}
+	var fields []validatedField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("validate")
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct && tag == "" {
+			for _, f := range validatedFields(sf.Type) {
+				f.index = append([]int{i}, f.index...)
+				fields = append(fields, f)
+			}
+			continue
+		}
+		if !sf.IsExported() || tag == "-" || (tag == "" && !hasNested(sf.Type)) {
+			continue
+		}
+		f := validatedField{index: []int{i}}
+		f.name, f.source = fieldName(sf)
+		f.rules, f.dive = parseRules(tag)
+		fields = append(fields, f)
+	}
+	validatedFieldCache.Store(t, fields)
+	return fields
+}
+
+// parseRules splits a tag into the rules of the field and those after dive.
+func parseRules(tag string) (rules, dive []ruleSpec) {
+	if tag == "" {
+		return nil, nil
+	}
+	target := &rules
+	for _, part := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch name {
+		case "":
+			continue
+		case "dive":
+			dive = []ruleSpec{}
+			target = &dive
+			continue
+		}
+		*target = append(*target, ruleSpec{name: name, param: param})
+	}
+	return rules, dive
+}
+
+// fieldName returns the name a field is reported under and where its value
+// came from.
+func fieldName(sf reflect.StructField) (name, source string) {
+	for _, source := range []string{"param", "query", "header"} {
+		if key := sf.Tag.Get(source); key != "" && key != "-" {
+			return key, source
+		}
+	}
+	for _, tag := range []string{"json", "form"} {
+		if key, _, _ := strings.Cut(sf.Tag.Get(tag), ","); key != "" && key != "-" {
+			return key, "body"
+		}
+	}
+	return sf.Name, "body"
+}
+
+func joinField(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// hasNested reports whether values of t may contain structs to validate.
+func hasNested(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return t != timeType
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return hasNested(t.Elem())
+	}
+	return false
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return keys
+}
+
+// isEmpty reports whether a value counts as missing for required.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.String, reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	}
+	return v.IsZero()
+}
+
+// sizeKind says how min, max and the like measure v.
+func sizeKind(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	}
+	return ""
+}
+
+// size is the length of strings (in characters), slices and maps, or the
+// value of numbers.
+func size(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// compare builds a rule that compares the size of a value with its number
+// parameter.
+func compare(ok func(size, param float64) bool) Rule {
+	return func(v reflect.Value, param string) bool {
+		n, err := strconv.ParseFloat(param, 64)
+		if v.Type() == durationType {
+			d, derr := time.ParseDuration(param)
+			n, err = float64(d), derr
+		}
+		if err != nil {
+			panic(fmt.Sprintf("validate: rule parameter %q is not a number", param))
+		}
+		s, valid := size(v)
+		return valid && ok(s, n)
+	}
+}
+
+// stringRule builds a rule that only string values can pass.
+func stringRule(ok func(string) bool) Rule {
+	return func(v reflect.Value, _ string) bool {
+		return v.Kind() == reflect.String && ok(v.String())
+	}
+}
+
+func allRunes(s string, ok func(rune) bool) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !ok(r) {
+			return false
+		}
+	}
+	return true
+}
+
+var builtinRules = map[string]Rule{
+	"min": compare(func(s, p float64) bool { return s >= p }),
+	"max": compare(func(s, p float64) bool { return s <= p }),
+	"len": compare(func(s, p float64) bool { return s == p }),
+	"gt":  compare(func(s, p float64) bool { return s > p }),
+	"gte": compare(func(s, p float64) bool { return s >= p }),
+	"lt":  compare(func(s, p float64) bool { return s < p }),
+	"lte": compare(func(s, p float64) bool { return s <= p }),
+	"eq": func(v reflect.Value, param string) bool {
+		return fmt.Sprint(v.Interface()) == param
+	},
+	"ne": func(v reflect.Value, param string) bool {
+		return fmt.Sprint(v.Interface()) != param
+	},
+	"oneof": func(v reflect.Value, param string) bool {
+		value := fmt.Sprint(v.Interface())
+		for _, allowed := range strings.Fields(param) {
+			if value == allowed {
+				return true
+			}
+		}
+		return false
+	},
+	"email": stringRule(func(s string) bool {
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	}),
+	"url": stringRule(func(s string) bool {
+		u, err := url.ParseRequestURI(s)
+		return err == nil && u.Scheme != "" && u.Host != ""
+	}),
+	"uuid": stringRule(func(s string) bool {
+		_, err := uuid.Parse(s)
+		return err == nil
+	}),
+	"alpha": stringRule(func(s string) bool { return allRunes(s, unicode.IsLetter) }),
+	"alphanum": stringRule(func(s string) bool {
+		return allRunes(s, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) })
+	}),
+	"numeric": stringRule(func(s string) bool {
+		_, err := strconv.ParseFloat(s, 64)
+		return err == nil
+	}),
+}
+
+var builtinMessages = map[string]string{
+	"required":   "is required",
+	"min":        "must be at least {param}",
+	"min.string": "must be at least {param} characters long",
+	"min.items":  "must contain at least {param} items",
+	"max":        "must be at most {param}",
+	"max.string": "must be at most {param} characters long",
+	"max.items":  "must contain at most {param} items",
+	"len":        "must be exactly {param}",
+	"len.string": "must be exactly {param} characters long",
+	"len.items":  "must contain exactly {param} items",
+	"gt":         "must be greater than {param}",
+	"gt.string":  "must be longer than {param} characters",
+	"gt.items":   "must contain more than {param} items",
+	"gte":        "must be at least {param}",
+	"gte.string": "must be at least {param} characters long",
+	"gte.items":  "must contain at least {param} items",
+	"lt":         "must be less than {param}",
+	"lt.string":  "must be shorter than {param} characters",
+	"lt.items":   "must contain fewer than {param} items",
+	"lte":        "must be at most {param}",
+	"lte.string": "must be at most {param} characters long",
+	"lte.items":  "must contain at most {param} items",
+	"eq":         "must be {param}",
+	"ne":         "must not be {param}",
+	"oneof":      "must be one of {param}",
+	"email":      "must be a valid email address",
+	"url":        "must be a valid URL",
+	"uuid":       "must be a valid UUID",
+	"alpha":      "must contain only letters",
+	"alphanum":   "must contain only letters and digits",
+	"numeric":    "must be a number",
+}
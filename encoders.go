@@ -0,0 +1,25 @@
+package TurboGo
+
+import "github.com/Dziqha/TurboGo/core"
+
+// ===== ENCODERS =====
+
+// RegisterEncoder adds or replaces the encoder for a media type, used by
+// Context.Encode and offered by Context.Negotiate after the ones already
+// registered. JSON, XML, MessagePack, CBOR and YAML are built in.
+//
+//	app.RegisterEncoder("application/x-protobuf", func(v any) ([]byte, error) {
+//		return proto.Marshal(v.(proto.Message))
+//	})
+func (a *App) RegisterEncoder(mime string, enc core.Encoder) *App {
+	a.encoders.Register(mime, enc)
+	return a
+}
+
+// JSONEncoder replaces the encoder behind Context.JSON, JSONPretty, JSONP
+// and negotiated JSON, for instance with a faster library:
+//
+//	app.JSONEncoder(sonic.Marshal)
+func (a *App) JSONEncoder(enc core.Encoder) *App {
+	return a.RegisterEncoder(core.MIMEJSON, enc)
+}
@@ -0,0 +1,112 @@
+package TurboGo
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/Dziqha/TurboGo/internal/router"
+)
+
+// ===== ERRORS =====
+
+// ErrorHandler sets the handler that writes the response for errors returned
+// by handlers, recorded with Context.SetError, or recovered from panics. It
+// applies to every route whose groups have no ErrorHandler of their own, and
+// to middleware and NotFound handlers. The default is DefaultErrorHandler.
+//
+//	app.ErrorHandler(func(c *core.Context, err error) {
+//		var httpErr *core.HTTPError
+//		if errors.As(err, &httpErr) {
+//			c.JSON(httpErr.Status, map[string]string{"error": httpErr.Text()})
+//			return
+//		}
+//		c.JSON(500, map[string]string{"error": "internal"})
+//	})
+func (a *App) ErrorHandler(h core.ErrorHandler) *App {
+	a.onError = h
+	return a
+}
+
+// problem is an RFC 9457 problem details document.
+type problem struct {
+	Type    string `json:"type"`
+	Title   string `json:"title"`
+	Status  int    `json:"status"`
+	Detail  string `json:"detail,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Details any    `json:"details,omitempty"`
+}
+
+// DefaultErrorHandler writes err as application/problem+json. A
+// *core.HTTPError sets the status, detail, code and details; any other
+// error, or a panic, becomes a 500 whose cause is logged but not sent.
+//
+//	{"type":"about:blank","title":"Not Found","status":404,"detail":"user not found","code":"user_not_found"}
+func DefaultErrorHandler(c *core.Context, err error) {
+	p := problem{Type: "about:blank", Status: http.StatusInternalServerError}
+
+	var httpErr *core.HTTPError
+	var panicErr *core.PanicError
+	switch {
+	case errors.As(err, &httpErr):
+		p.Status = httpErr.Status
+		if httpErr.Message != "" {
+			p.Detail = httpErr.Message
+		}
+		p.Code, p.Details = httpErr.Code, httpErr.Details
+		if p.Status >= 500 {
+			c.Logger().Error("%s %s: %v", c.Ctx.Method(), c.Ctx.Path(), err)
+		}
+	case errors.As(err, &panicErr):
+		c.Logger().Error("%s %s: %v\n%s", c.Ctx.Method(), c.Ctx.Path(), err, panicErr.Stack)
+	default:
+		c.Logger().Error("%s %s: %v", c.Ctx.Method(), c.Ctx.Path(), err)
+	}
+	p.Title = http.StatusText(p.Status)
+
+	body, _ := json.Marshal(p)
+	c.Ctx.Response.ResetBody()
+	c.Ctx.SetStatusCode(p.Status)
+	c.Ctx.SetContentType("application/problem+json")
+	c.Ctx.SetBody(body)
+}
+
+// run runs the request chain and hands the error it ends with, or a panic,
+// to the closest error handler of route.
+func (a *App) run(c *core.Context, route *router.Route) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			c.SetError(&core.PanicError{Value: rec, Stack: debug.Stack()})
+		}
+		if err := c.Err(); err != nil {
+			a.handleError(c, route, err)
+		}
+	}()
+	c.Next()
+}
+
+// handleError calls the error handler of route's closest group, else the
+// app's, else DefaultErrorHandler. If that handler panics in turn, a bare
+// 500 is written.
+func (a *App) handleError(c *core.Context, route *router.Route, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			a.logger.Error("error handler panicked on %v: %v", err, rec)
+			c.Ctx.Response.ResetBody()
+			c.Ctx.SetStatusCode(http.StatusInternalServerError)
+			c.Ctx.SetBodyString(http.StatusText(http.StatusInternalServerError))
+		}
+	}()
+
+	h := a.groups[route].errorHandler()
+	if h == nil {
+		h = a.onError
+	}
+	if h == nil {
+		h = DefaultErrorHandler
+	}
+	h(c, err)
+}
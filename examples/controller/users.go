@@ -8,35 +8,32 @@ import (
 
 // --- Input struct ---
 type CreateUserInput struct {
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	Name  string `json:"name" validate:"required,min=3"`
+	Email string `json:"email" validate:"required,email"`
 }
 
 // --- HTTP Handler ---
-func CreateUserHandler(c *core.Context) {
+func CreateUserHandler(c *core.Context) error {
 	var input CreateUserInput
 
-	if err := c.BindJSON(&input); err != nil {
-		c.JSON(400, map[string]string{"error": "Invalid JSON"})
-		return
+	if err := c.BindAndValidate(&input); err != nil {
+		return err
 	}
 
 	// 🔁 Marshal payload ke []byte
 	payload, err := json.Marshal(input)
 	if err != nil {
-		c.JSON(500, map[string]string{"error": "Failed to encode"})
-		return
+		return err
 	}
 
 	if err := c.MustQueue().EnqueueAll("user:welcome-email", payload); err != nil {
-		c.JSON(500, map[string]string{"error": "Queue error"})
-		return
+		return fmt.Errorf("queue: %w", err)
 	}
 	if err := c.MustPubsub().PublishAll("user.created", payload); err != nil {
-		c.JSON(500, map[string]string{"error": "Pubsub error"})
-		return
+		return fmt.Errorf("pubsub: %w", err)
 	}
 	c.JSON(201, map[string]string{"message": "User created"})
+	return nil
 }
 
 // --- Queue Worker ---
@@ -0,0 +1,220 @@
+package TurboGo
+
+import (
+	"strings"
+
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/Dziqha/TurboGo/internal/router"
+)
+
+// Group registers routes under a shared prefix, middleware and metadata.
+// Groups nest: a child inherits everything from its parents, and settings
+// are resolved when a route is added, so Use and Meta apply to routes
+// registered after the call.
+//
+//	api := app.Group("/api", auth)
+//	admin := api.Group("/v1").Group("/admin")
+//	admin.Use(requireAdmin)
+//	admin.Get("/stats", stats) // GET /api/v1/admin/stats, auth then requireAdmin
+type Group struct {
+	prefix     string
+	middleware []core.Handler
+	mwNames    []string
+	app        *App
+	parent     *Group
+
+	router *router.SingleThreadedRouter // nil for the default host table
+	host   string
+
+	metadata map[string]any
+	onError  core.ErrorHandler
+}
+
+// scopedHandler is a handler bound to the routes below a path prefix.
+type scopedHandler struct {
+	router  *router.SingleThreadedRouter
+	prefix  string
+	handler core.Handler
+}
+
+// Group creates a group of routes under prefix. Middleware, like route
+// handlers, may be core.Handler or core.HandlerFunc values.
+func (a *App) Group(prefix string, middlewares ...any) *Group {
+	return &Group{
+		prefix:     prefix,
+		middleware: core.ToHandlers(middlewares),
+		mwNames:    handlerNames(middlewares),
+		app:        a,
+	}
+}
+
+// Group creates a child group whose prefix is appended to g's.
+func (g *Group) Group(prefix string, middlewares ...any) *Group {
+	return &Group{
+		prefix:     prefix,
+		middleware: core.ToHandlers(middlewares),
+		mwNames:    handlerNames(middlewares),
+		app:        g.app,
+		parent:     g,
+		router:     g.router,
+		host:       g.host,
+	}
+}
+
+// Use appends middleware to the group. It runs for routes added afterwards,
+// on this group and on its children.
+func (g *Group) Use(middlewares ...any) *Group {
+	g.middleware = append(g.middleware, core.ToHandlers(middlewares)...)
+	g.mwNames = append(g.mwNames, handlerNames(middlewares)...)
+	return g
+}
+
+// Meta sets a metadata value inherited by every route added afterwards to
+// the group or its children. A route may override it with Route.Meta.
+func (g *Group) Meta(key string, value any) *Group {
+	if g.metadata == nil {
+		g.metadata = make(map[string]any)
+	}
+	g.metadata[key] = value
+	return g
+}
+
+// NotFound sets the handler used for unmatched paths below the group's
+// prefix. The deepest group covering the path wins over App.NotFound.
+func (g *Group) NotFound(h core.Handler) *Group {
+	a := g.app
+	scope := scopedHandler{router: g.targetRouter(), prefix: g.fullPrefix(), handler: h}
+	for i, s := range a.notFounds {
+		if s.router == scope.router && s.prefix == scope.prefix {
+			a.notFounds[i] = scope
+			return g
+		}
+	}
+	a.notFounds = append(a.notFounds, scope)
+	return g
+}
+
+// ErrorHandler sets the handler that writes the response when a route of
+// the group, or of a child group without its own handler, returns an error
+// or panics. It takes precedence over App.ErrorHandler.
+func (g *Group) ErrorHandler(h core.ErrorHandler) *Group {
+	g.onError = h
+	return g
+}
+
+func (g *Group) fullPrefix() string {
+	if g.parent == nil {
+		return g.prefix
+	}
+	return g.parent.fullPrefix() + g.prefix
+}
+
+// chain returns fresh slices with the middleware of g's ancestors and g,
+// and their names.
+func (g *Group) chain() ([]core.Handler, []string) {
+	var parent []core.Handler
+	var names []string
+	if g.parent != nil {
+		parent, names = g.parent.chain()
+	}
+	return append(parent, g.middleware...), append(names, g.mwNames...)
+}
+
+func (g *Group) meta() map[string]any {
+	if g.parent == nil {
+		return g.metadata
+	}
+	merged := make(map[string]any)
+	for k, v := range g.parent.meta() {
+		merged[k] = v
+	}
+	for k, v := range g.metadata {
+		merged[k] = v
+	}
+	return merged
+}
+
+func (g *Group) errorHandler() core.ErrorHandler {
+	for ; g != nil; g = g.parent {
+		if g.onError != nil {
+			return g.onError
+		}
+	}
+	return nil
+}
+
+func (g *Group) targetRouter() *router.SingleThreadedRouter {
+	if g.router == nil {
+		return g.app.singleRouter
+	}
+	return g.router
+}
+
+// scopedNotFound returns the NotFound handler of the deepest group on rt, or
+// on the default router, whose prefix covers path.
+func (a *App) scopedNotFound(rt *router.SingleThreadedRouter, path string) core.Handler {
+	for _, target := range []*router.SingleThreadedRouter{rt, a.singleRouter} {
+		var best *scopedHandler
+		for i := range a.notFounds {
+			s := &a.notFounds[i]
+			if s.router != target || !coversPath(s.prefix, path) {
+				continue
+			}
+			if best == nil || len(s.prefix) > len(best.prefix) {
+				best = s
+			}
+		}
+		if best != nil {
+			return best.handler
+		}
+	}
+	return nil
+}
+
+// coversPath reports whether path is prefix or lies below it: "/api" covers
+// "/api" and "/api/users" but not "/apiary".
+func coversPath(prefix, path string) bool {
+	rest, ok := strings.CutPrefix(path, prefix)
+	return ok && (rest == "" || rest[0] == '/' || prefix == "" || strings.HasSuffix(prefix, "/"))
+}
+
+func (g *Group) Add(methods []string, path string, h any, hs ...any) *router.Route {
+	return g.app.add(g, methods, path, append([]any{h}, hs...))
+}
+
+func (g *Group) Get(path string, h any, hs ...any) *router.Route {
+	return g.Add([]string{"GET"}, path, h, hs...)
+}
+
+func (g *Group) Post(path string, h any, hs ...any) *router.Route {
+	return g.Add([]string{"POST"}, path, h, hs...)
+}
+
+func (g *Group) Put(path string, h any, hs ...any) *router.Route {
+	return g.Add([]string{"PUT"}, path, h, hs...)
+}
+
+func (g *Group) Delete(path string, h any, hs ...any) *router.Route {
+	return g.Add([]string{"DELETE"}, path, h, hs...)
+}
+
+func (g *Group) Patch(path string, h any, hs ...any) *router.Route {
+	return g.Add([]string{"PATCH"}, path, h, hs...)
+}
+
+func (g *Group) Options(path string, h any, hs ...any) *router.Route {
+	return g.Add([]string{"OPTIONS"}, path, h, hs...)
+}
+
+func (g *Group) Head(path string, h any, hs ...any) *router.Route {
+	return g.Add([]string{"HEAD"}, path, h, hs...)
+}
+
+func (g *Group) Connect(path string, h any, hs ...any) *router.Route {
+	return g.Add([]string{"CONNECT"}, path, h, hs...)
+}
+
+func (g *Group) All(path string, h any, hs ...any) *router.Route {
+	methods := []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"}
+	return g.Add(methods, path, h, hs...)
+}
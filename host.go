@@ -0,0 +1,133 @@
+package TurboGo
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/Dziqha/TurboGo/internal/router"
+	"github.com/valyala/fasthttp"
+)
+
+// hostRoute is the route table of one host pattern such as
+// "api.example.com" or ":tenant.example.com".
+type hostRoute struct {
+	pattern string
+	labels  []string // lower-cased; ":name" labels capture one host label
+	dynamic bool
+	router  *router.SingleThreadedRouter
+}
+
+func newHostRoute(pattern string) *hostRoute {
+	pattern = strings.ToLower(strings.TrimSuffix(pattern, "."))
+	if pattern == "" {
+		panic("host pattern cannot be empty")
+	}
+	h := &hostRoute{
+		pattern: pattern,
+		labels:  strings.Split(pattern, "."),
+		router:  router.NewSingleThreadedRouter(),
+	}
+	for _, l := range h.labels {
+		if l == "" || l == ":" {
+			panic(fmt.Sprintf("invalid host pattern %q", pattern))
+		}
+		if l[0] == ':' {
+			h.dynamic = true
+		}
+	}
+	return h
+}
+
+// match reports whether host fits the pattern, appending captured labels to ps.
+func (h *hostRoute) match(host string, ps *router.Params) bool {
+	mark := len(*ps)
+	for i, l := range h.labels {
+		label := host
+		if dot := strings.IndexByte(host, '.'); dot >= 0 {
+			label, host = host[:dot], host[dot+1:]
+		} else {
+			host = ""
+			if i != len(h.labels)-1 {
+				*ps = (*ps)[:mark]
+				return false
+			}
+		}
+
+		if l[0] == ':' {
+			if label == "" {
+				*ps = (*ps)[:mark]
+				return false
+			}
+			*ps = append(*ps, router.Param{Key: l[1:], Value: label})
+		} else if !strings.EqualFold(l, label) {
+			*ps = (*ps)[:mark]
+			return false
+		}
+	}
+	if host != "" {
+		*ps = (*ps)[:mark]
+		return false
+	}
+	return true
+}
+
+// Host returns a route scope for requests whose Host header matches pattern.
+// Labels starting with ':' capture a host label as a parameter, readable with
+// Context.Param like path parameters:
+//
+//	api := app.Host("api.example.com")
+//	tenant := app.Host(":tenant.example.com")
+//	tenant.Get("/", func(c *core.Context) { c.Text(200, c.Param("tenant")) })
+//
+// Exact hosts are tried before patterns with parameters. Requests for an
+// unknown host, or for a path the host does not serve, use the default routes.
+func (a *App) Host(pattern string) *Group {
+	h := newHostRoute(pattern)
+	for _, existing := range a.hosts {
+		if existing.pattern == h.pattern {
+			return &Group{app: a, router: existing.router, host: existing.pattern}
+		}
+	}
+
+	h.router.SetConfig(a.singleRouter.Config())
+	a.hosts = append(a.hosts, h)
+	a.sortHosts()
+	return &Group{app: a, router: h.router, host: h.pattern}
+}
+
+// sortHosts keeps exact hosts ahead of dynamic ones, preserving registration
+// order otherwise.
+func (a *App) sortHosts() {
+	exact := make([]*hostRoute, 0, len(a.hosts))
+	dynamic := make([]*hostRoute, 0, len(a.hosts))
+	for _, h := range a.hosts {
+		if h.dynamic {
+			dynamic = append(dynamic, h)
+		} else {
+			exact = append(exact, h)
+		}
+	}
+	a.hosts = append(exact, dynamic...)
+}
+
+// matchHost picks the router for the request's Host header, appending host
+// parameters to ps. It returns the default router when no host matches.
+func (a *App) matchHost(ctx *fasthttp.RequestCtx, ps *router.Params) *router.SingleThreadedRouter {
+	host := hostname(string(ctx.Host()))
+	for _, h := range a.hosts {
+		if h.match(host, ps) {
+			return h.router
+		}
+	}
+	return a.singleRouter
+}
+
+// hostname lower-cases a Host header value and strips the port and a
+// trailing dot.
+func hostname(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
@@ -1,7 +1,28 @@
 package cache
 
+import "time"
+
+// Store is the key-value store behind an Engine. InMemCache implements it.
+type Store interface {
+	Set(key string, value []byte, ttl time.Duration)
+	Get(key string) ([]byte, bool)
+	Delete(key string) bool
+	Exists(key string) bool
+	SetEx(key string, value []byte, seconds int)
+	SetNX(key string, value []byte, ttl time.Duration) bool
+	TTL(key string) time.Duration
+	Size() int
+	Range(fn func(key string, value []byte))
+	Close()
+}
+
 type Engine struct {
-	Memory *InMemCache // from inmem.go
+	Memory Store // an *InMemCache unless built with NewEngineWith
+}
+
+// Close stops the expiry goroutine and drops every entry.
+func (e *Engine) Close() {
+	e.Memory.Close()
 }
 
 func NewEngine() (*Engine, error) {
@@ -9,3 +30,8 @@ func NewEngine() (*Engine, error) {
 		Memory: NewInMem(),
 	}, nil
 }
+
+// NewEngineWith returns an engine backed by store.
+func NewEngineWith(store Store) *Engine {
+	return &Engine{Memory: store}
+}
@@ -0,0 +1,121 @@
+package persist
+
+import (
+	"os"
+	"time"
+)
+
+// SyncPolicy decides when an append-only log is flushed to disk.
+type SyncPolicy uint8
+
+const (
+	SyncAlways   SyncPolicy = iota // fsync after every write
+	SyncBatch                      // fsync after every BatchSize writes
+	SyncInterval                   // fsync every Interval from a background loop
+)
+
+// Options configures the persistence file of the queue and pubsub engines.
+type Options struct {
+	Sync         SyncPolicy
+	BatchSize    int           // writes per fsync with SyncBatch
+	SyncInterval time.Duration // period of SyncInterval
+
+	Retention       time.Duration // entries older than this are dropped on compaction
+	CompactInterval time.Duration // how often the file is compacted
+	// MaxFileSize compacts early once the file grows past this many bytes;
+	// 0 = no limit. When a compaction leaves the file above the limit, the
+	// next one waits until it has grown by another MaxFileSize/2.
+	MaxFileSize int64
+}
+
+// DefaultOptions returns the options used when none are given.
+func DefaultOptions() Options {
+	return Options{
+		Sync:            SyncAlways,
+		BatchSize:       100,
+		SyncInterval:    time.Second,
+		Retention:       24 * time.Hour,
+		CompactInterval: 10 * time.Minute,
+	}
+}
+
+// WithDefaults fills zero fields from DefaultOptions.
+func (o Options) WithDefaults() Options {
+	def := DefaultOptions()
+	if o.BatchSize <= 0 {
+		o.BatchSize = def.BatchSize
+	}
+	if o.SyncInterval <= 0 {
+		o.SyncInterval = def.SyncInterval
+	}
+	if o.Retention <= 0 {
+		o.Retention = def.Retention
+	}
+	if o.CompactInterval <= 0 {
+		o.CompactInterval = def.CompactInterval
+	}
+	return o
+}
+
+// Syncer counts writes to a log file and syncs it as the policy requires.
+// Callers serialize access with the lock guarding the file.
+type Syncer struct {
+	opts    Options
+	pending int
+	size    int64
+	base    int64 // size after the last Reset, i.e. open or compaction
+}
+
+func NewSyncer(opts Options) *Syncer {
+	return &Syncer{opts: opts}
+}
+
+// Wrote records n bytes written to f and syncs f when the policy says so.
+// It reports whether the file has outgrown MaxFileSize, and has grown
+// enough since the last compaction for another one to be worth it.
+func (s *Syncer) Wrote(f *os.File, n int) (full bool, err error) {
+	s.pending++
+	s.size += int64(n)
+	switch s.opts.Sync {
+	case SyncAlways:
+		err = s.Flush(f)
+	case SyncBatch:
+		if s.pending >= s.opts.BatchSize {
+			err = s.Flush(f)
+		}
+	}
+	return s.full(), err
+}
+
+func (s *Syncer) full() bool {
+	max := s.opts.MaxFileSize
+	if max <= 0 || s.size <= max {
+		return false
+	}
+	// Retention may keep everything, so compacting again right away would
+	// not help; wait for real growth instead of compacting on every write.
+	return s.size-s.base > max/2
+}
+
+// Flush syncs f if anything was written since the last sync.
+func (s *Syncer) Flush(f *os.File) error {
+	if s.pending == 0 || f == nil {
+		return nil
+	}
+	s.pending = 0
+	return f.Sync()
+}
+
+// Reset records the size of a freshly opened or rewritten file.
+func (s *Syncer) Reset(f *os.File) {
+	s.pending = 0
+	s.size = 0
+	s.base = 0
+	if f == nil {
+		return
+	}
+	if info, err := f.Stat(); err == nil {
+		s.size = info.Size()
+	}
+	s.base = s.size
+}
@@ -1,22 +1,57 @@
 package pubsub
 
+import "github.com/Dziqha/TurboGo/internal/persist"
+
+// Backend is an event bus that can stand in for the memory and storage buses
+// of an Engine.
+type Backend interface {
+	PublishAll(topic string, data []byte) error
+	SubscribeAll(topic string) <-chan []byte
+	Close()
+}
+
 type Engine struct {
 	Memory  *EventBus
 	Storage *PersistentEventBus
+
+	backend Backend
 }
 
 func NewEngine() (*Engine, error) {
-	persist, err := NewPersistent("data/pubsub.json")
+	return NewEngineWithFile("data/pubsub.json")
+}
+
+// NewEngineWithFile creates an engine persisting its messages to logFile.
+func NewEngineWithFile(logFile string) (*Engine, error) {
+	return NewEngineWithOptions(logFile, persist.DefaultOptions())
+}
+
+// NewEngineWithOptions creates an engine persisting its messages to logFile
+// with the given persistence options.
+func NewEngineWithOptions(logFile string, opts persist.Options) (*Engine, error) {
+	storage, err := NewPersistentWithOptions(logFile, opts)
 	if err != nil {
 		return nil, err
 	}
 	return &Engine{
 		Memory:  NewInMem(),
-		Storage: persist,
+		Storage: storage,
 	}, nil
 }
 
+// NewEngineWith returns an engine handing every call to backend. Its Memory
+// and Storage are nil.
+func NewEngineWith(backend Backend) *Engine {
+	if e, ok := backend.(*Engine); ok {
+		return e
+	}
+	return &Engine{backend: backend}
+}
+
 func (e *Engine) PublishAll(topic string, data []byte) error {
+	if e.backend != nil {
+		return e.backend.PublishAll(topic, data)
+	}
 	if err := e.Storage.Publish(topic, data); err != nil {
 		return err
 	}
@@ -26,7 +61,20 @@ func (e *Engine) PublishAll(topic string, data []byte) error {
 	return nil
 }
 
+// Close closes both buses, ending every subscription, and the log file.
+func (e *Engine) Close() {
+	if e.backend != nil {
+		e.backend.Close()
+		return
+	}
+	e.Storage.Close()
+	e.Memory.Close()
+}
+
 func (p *Engine) SubscribeAll(topic string) <-chan []byte {
+	if p.backend != nil {
+		return p.backend.SubscribeAll(topic)
+	}
 	memCh := p.Memory.Subscribe(topic)
 	storageCh := p.Storage.Subscribe(topic)
 
@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"sync"
 	"time"
+
+	"github.com/Dziqha/TurboGo/internal/persist"
 )
 
 type Message struct {
@@ -25,22 +27,58 @@ type PersistentEventBus struct {
 	replayMode bool
 	messageID  uint64
 	idMutex    sync.Mutex
+
+	opts       persist.Options
+	syncer     *persist.Syncer // guarded by writeMutex
+	compactNow chan struct{}   // asks AutoCompact for an early run
+	stop       chan struct{}   // stops AutoCompact
+	closeOnce  sync.Once
 }
 
 func NewPersistent(logFile string) (*PersistentEventBus, error) {
+	return NewPersistentWithOptions(logFile, persist.DefaultOptions())
+}
+
+// NewPersistentWithOptions creates a bus logging to logFile with the given
+// fsync policy, message retention and compaction schedule.
+func NewPersistentWithOptions(logFile string, opts persist.Options) (*PersistentEventBus, error) {
 	inMem := NewInMem()
+	opts = opts.WithDefaults()
 
 	peb := &PersistentEventBus{
-		EventBus:  inMem,
-		logFile:   logFile,
-		messageID: 1,
+		EventBus:   inMem,
+		logFile:    logFile,
+		messageID:  1,
+		opts:       opts,
+		syncer:     persist.NewSyncer(opts),
+		compactNow: make(chan struct{}, 1),
+		stop:       make(chan struct{}),
 	}
 
-	peb.AutoCompact(10 * time.Minute)
+	peb.AutoCompact(opts.CompactInterval)
+	if opts.Sync == persist.SyncInterval {
+		go peb.syncLoop(opts.SyncInterval)
+	}
 
 	return peb, nil
 }
 
+// syncLoop flushes the log file every interval under SyncInterval.
+func (peb *PersistentEventBus) syncLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			peb.writeMutex.Lock()
+			peb.syncer.Flush(peb.file)
+			peb.writeMutex.Unlock()
+		case <-peb.stop:
+			return
+		}
+	}
+}
+
 func (p *PersistentEventBus) ensureFile() error {
 	if p.file != nil {
 		return nil
@@ -53,6 +91,7 @@ func (p *PersistentEventBus) ensureFile() error {
 		return fmt.Errorf("failed to open log file: %v", err)
 	}
 	p.file = file
+	p.syncer.Reset(file)
 	return nil
 }
 
@@ -92,12 +131,19 @@ func (peb *PersistentEventBus) logMessage(msg Message) error {
 		return err
 	}
 
-	_, err = peb.file.Write(append(data, '\n'))
+	n, err := peb.file.Write(append(data, '\n'))
 	if err != nil {
 		return err
 	}
 
-	return peb.file.Sync()
+	full, err := peb.syncer.Wrote(peb.file, n)
+	if full {
+		select {
+		case peb.compactNow <- struct{}{}:
+		default:
+		}
+	}
+	return err
 }
 
 func (peb *PersistentEventBus) Replay(fromTime *time.Time, toTime *time.Time) error {
@@ -171,7 +217,7 @@ func (peb *PersistentEventBus) Compact() error {
 	}
 	defer temp.Close()
 
-	cutoff := time.Now().Add(-24 * time.Hour)
+	cutoff := time.Now().Add(-peb.opts.Retention)
 	messages, err := peb.GetMessageHistory("", 0)
 	if err != nil {
 		os.Remove(tempFile)
@@ -200,6 +246,7 @@ func (peb *PersistentEventBus) Compact() error {
 	}
 
 	peb.file, err = os.OpenFile(peb.logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	peb.syncer.Reset(peb.file)
 	peb.writeMutex.Unlock()
 
 	fmt.Printf("🧹 Pubsub compact done: %d messages kept\n", len(messages))
@@ -212,7 +259,13 @@ func (peb *PersistentEventBus) AutoCompact(interval time.Duration) {
 		ticker := time.NewTicker(interval)
 		defer ticker.Stop()
 
-		for range ticker.C {
+		for {
+			select {
+			case <-ticker.C:
+			case <-peb.compactNow:
+			case <-peb.stop:
+				return
+			}
 			if err := peb.Compact(); err != nil {
 				fmt.Println("❌ Pubsub compact error:", err)
 			}
@@ -221,11 +274,16 @@ func (peb *PersistentEventBus) AutoCompact(interval time.Duration) {
 }
 
 func (peb *PersistentEventBus) Close() {
-	peb.writeMutex.Lock()
-	if peb.file != nil {
-		peb.file.Close()
-	}
-	peb.writeMutex.Unlock()
+	peb.closeOnce.Do(func() {
+		close(peb.stop)
+
+		peb.writeMutex.Lock()
+		if peb.file != nil {
+			peb.file.Sync()
+			peb.file.Close()
+		}
+		peb.writeMutex.Unlock()
 
-	peb.EventBus.Close()
+		peb.EventBus.Close()
+	})
 }
@@ -1,22 +1,62 @@
 package queue
 
+import (
+	"context"
+	"errors"
+
+	"github.com/Dziqha/TurboGo/internal/persist"
+)
+
+// Backend is a queue that can stand in for the memory and storage queues of
+// an Engine.
+type Backend interface {
+	EnqueueAll(queue string, task []byte) error
+	RegisterWorkerAll(queue string, handler func([]byte) error)
+	Shutdown(ctx context.Context) error
+}
+
 type Engine struct {
 	Memory  *TaskQueue
 	Storage *PersistentTaskQueue
+
+	backend Backend
 }
 
 func NewEngine() (*Engine, error) {
-	persist, err := NewPersistent("data/queue.json")
+	return NewEngineWithFile("data/queue.json")
+}
+
+// NewEngineWithFile creates an engine persisting its tasks to logFile.
+func NewEngineWithFile(logFile string) (*Engine, error) {
+	return NewEngineWithOptions(logFile, persist.DefaultOptions())
+}
+
+// NewEngineWithOptions creates an engine persisting its tasks to logFile
+// with the given persistence options.
+func NewEngineWithOptions(logFile string, opts persist.Options) (*Engine, error) {
+	storage, err := NewPersistentWithOptions(logFile, opts)
 	if err != nil {
 		return nil, err
 	}
 	return &Engine{
 		Memory:  NewInMem(),
-		Storage: persist,
+		Storage: storage,
 	}, nil
 }
 
+// NewEngineWith returns an engine handing every call to backend. Its Memory
+// and Storage are nil.
+func NewEngineWith(backend Backend) *Engine {
+	if e, ok := backend.(*Engine); ok {
+		return e
+	}
+	return &Engine{backend: backend}
+}
+
 func (e *Engine) EnqueueAll(queue string, task []byte) error {
+	if e.backend != nil {
+		return e.backend.EnqueueAll(queue, task)
+	}
 	if err := e.Storage.Enqueue(queue, task); err != nil {
 		return err
 	}
@@ -26,7 +66,20 @@ func (e *Engine) EnqueueAll(queue string, task []byte) error {
 	return nil
 }
 
+// Shutdown lets the workers of both queues finish their current task, then
+// closes the queues and the persistence file.
+func (e *Engine) Shutdown(ctx context.Context) error {
+	if e.backend != nil {
+		return e.backend.Shutdown(ctx)
+	}
+	return errors.Join(e.Memory.Shutdown(ctx), e.Storage.Shutdown(ctx))
+}
+
 func (q *Engine) RegisterWorkerAll(queueName string, handler func([]byte) error) {
+	if q.backend != nil {
+		q.backend.RegisterWorkerAll(queueName, handler)
+		return
+	}
 	q.Memory.RegisterWorker(queueName, handler)
 	q.Storage.RegisterWorker(queueName, handler)
 }
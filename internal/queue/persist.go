@@ -2,12 +2,15 @@ package queue
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
 	"time"
+
+	"github.com/Dziqha/TurboGo/internal/persist"
 )
 
 type Task struct {
@@ -28,27 +31,63 @@ type PersistentTaskQueue struct {
 	idMutex    sync.Mutex
 	tasks      map[string]*Task
 	tasksMutex sync.RWMutex
+
+	opts       persist.Options
+	syncer     *persist.Syncer // guarded by writeMutex
+	compactNow chan struct{}   // asks AutoCleanup for an early run
+	stop       chan struct{}   // stops AutoCleanup
+	closeOnce  sync.Once
 }
 
 func NewPersistent(logFile string) (*PersistentTaskQueue, error) {
+	return NewPersistentWithOptions(logFile, persist.DefaultOptions())
+}
+
+// NewPersistentWithOptions creates a queue logging to logFile with the given
+// fsync policy, retention of completed tasks and cleanup schedule.
+func NewPersistentWithOptions(logFile string, opts persist.Options) (*PersistentTaskQueue, error) {
 	inMem := NewInMem()
+	opts = opts.WithDefaults()
 
 	ptq := &PersistentTaskQueue{
-		TaskQueue: inMem,
-		logFile:   logFile,
-		taskID:    1,
-		tasks:     make(map[string]*Task),
+		TaskQueue:  inMem,
+		logFile:    logFile,
+		taskID:     1,
+		tasks:      make(map[string]*Task),
+		opts:       opts,
+		syncer:     persist.NewSyncer(opts),
+		compactNow: make(chan struct{}, 1),
+		stop:       make(chan struct{}),
 	}
 
 	if err := ptq.loadPendingTasks(); err != nil {
 		return nil, fmt.Errorf("failed to load pending tasks: %v", err)
 	}
 
-	ptq.AutoCleanup(10*time.Minute, 24*time.Hour)
+	ptq.AutoCleanup(opts.CompactInterval, opts.Retention)
+	if opts.Sync == persist.SyncInterval {
+		go ptq.syncLoop(opts.SyncInterval)
+	}
 
 	return ptq, nil
 }
 
+// syncLoop flushes the log file every interval under SyncInterval.
+func (ptq *PersistentTaskQueue) syncLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			ptq.writeMutex.Lock()
+			ptq.syncer.Flush(ptq.file)
+			ptq.writeMutex.Unlock()
+		case <-ptq.stop:
+			return
+		}
+	}
+}
+
 func (ptq *PersistentTaskQueue) ensureFile() error {
 	if ptq.file != nil {
 		return nil
@@ -61,6 +100,7 @@ func (ptq *PersistentTaskQueue) ensureFile() error {
 		return fmt.Errorf("failed to open log file: %v", err)
 	}
 	ptq.file = file
+	ptq.syncer.Reset(file)
 	return nil
 }
 
@@ -140,12 +180,19 @@ func (ptq *PersistentTaskQueue) logTask(task *Task) error {
 		return err
 	}
 
-	_, err = ptq.file.Write(append(data, '\n'))
+	n, err := ptq.file.Write(append(data, '\n'))
 	if err != nil {
 		return err
 	}
 
-	return ptq.file.Sync()
+	full, err := ptq.syncer.Wrote(ptq.file, n)
+	if full {
+		select {
+		case ptq.compactNow <- struct{}{}:
+		default:
+		}
+	}
+	return err
 }
 
 func (ptq *PersistentTaskQueue) updateTaskStatus(taskID, status string) {
@@ -290,19 +337,33 @@ func (ptq *PersistentTaskQueue) rewriteLogFile() error {
 	}
 
 	ptq.file, err = os.OpenFile(ptq.logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	ptq.syncer.Reset(ptq.file)
 	ptq.writeMutex.Unlock()
 
 	return err
 }
 
 func (ptq *PersistentTaskQueue) Close() {
-	ptq.writeMutex.Lock()
-	if ptq.file != nil {
-		ptq.file.Close()
-	}
-	ptq.writeMutex.Unlock()
+	ptq.closeOnce.Do(func() {
+		close(ptq.stop)
 
-	ptq.TaskQueue.Close()
+		ptq.writeMutex.Lock()
+		if ptq.file != nil {
+			ptq.file.Sync()
+			ptq.file.Close()
+		}
+		ptq.writeMutex.Unlock()
+
+		ptq.TaskQueue.Close()
+	})
+}
+
+// Shutdown waits for running tasks to finish so their status is logged,
+// then closes the log file. Pending tasks are replayed on the next start.
+func (ptq *PersistentTaskQueue) Shutdown(ctx context.Context) error {
+	err := ptq.Drain(ctx)
+	ptq.Close()
+	return err
 }
 
 func (ptq *PersistentTaskQueue) AutoCleanup(interval time.Duration, olderThan time.Duration) {
@@ -310,7 +371,13 @@ func (ptq *PersistentTaskQueue) AutoCleanup(interval time.Duration, olderThan ti
 		ticker := time.NewTicker(interval)
 		defer ticker.Stop()
 
-		for range ticker.C {
+		for {
+			select {
+			case <-ticker.C:
+			case <-ptq.compactNow:
+			case <-ptq.stop:
+				return
+			}
 			if err := ptq.Cleanup(olderThan); err != nil {
 				fmt.Println("❌ Queue auto-cleanup error:", err)
 			}
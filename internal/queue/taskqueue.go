@@ -13,6 +13,7 @@ type TaskQueue struct {
 	mu                   sync.RWMutex
 	queues               map[string]chan []byte
 	workers              map[string][]context.CancelFunc
+	running              sync.WaitGroup // worker goroutines still alive
 	closed               bool
 	AllowMultipleWorkers bool // ✅ optional: true = banyak worker per queue
 }
@@ -81,8 +82,13 @@ func (q *TaskQueue) RegisterWorker(queue string, handler func([]byte) error) err
 	ctx, cancel := context.WithCancel(context.Background())
 	q.workers[queue] = append(q.workers[queue], cancel) // ✅ now supports multiple workers if allowed
 
+	q.running.Add(1)
 	go func() {
+		defer q.running.Done()
 		for {
+			if ctx.Err() != nil {
+				return
+			}
 			select {
 			case msg, ok := <-ch:
 				if !ok {
@@ -130,6 +136,39 @@ func (q *TaskQueue) GetQueues() []string {
 	return queues
 }
 
+// Drain stops every worker once its current task is done and waits for
+// them until ctx expires. Tasks not yet picked up stay in the queue.
+func (q *TaskQueue) Drain(ctx context.Context) error {
+	q.mu.Lock()
+	for queue, cancels := range q.workers {
+		for _, cancel := range cancels {
+			cancel()
+		}
+		delete(q.workers, queue)
+	}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for queue workers: %w", ctx.Err())
+	}
+}
+
+// Shutdown drains the workers, then closes the queue.
+func (q *TaskQueue) Shutdown(ctx context.Context) error {
+	err := q.Drain(ctx)
+	q.Close()
+	return err
+}
+
 func (q *TaskQueue) Close() {
 	q.mu.Lock()
 	defer q.mu.Unlock()
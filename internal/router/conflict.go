@@ -0,0 +1,143 @@
+package router
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ===== CONFLICT DETECTION =====
+
+// ConflictError reports a route that is ambiguous with, or a duplicate of,
+// a route registered earlier for the same method.
+type ConflictError struct {
+	Method   string
+	Route    *Route // the route being registered
+	Existing *Route // the route it collides with
+	Reason   string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("route conflict: %s %s%s conflicts with %s %s%s: %s",
+		e.Method, e.Route.Path, sourceSuffix(e.Route),
+		e.Method, e.Existing.Path, sourceSuffix(e.Existing),
+		e.Reason)
+}
+
+func sourceSuffix(r *Route) string {
+	if r.Source == "" {
+		return ""
+	}
+	return " (" + r.Source + ")"
+}
+
+// Check reports whether registering pattern for method would duplicate an
+// existing route or give a parameter or wildcard a different name than an
+// existing one at the same position. It does not modify the router.
+func (r *SingleThreadedRouter) Check(method, pattern string, route *Route) error {
+	root := r.tree(method)
+	if root == nil {
+		return nil
+	}
+	tokens, err := tokenize(pattern)
+	if err != nil {
+		return err
+	}
+	for _, variant := range expandOptional(tokens) {
+		if err := root.check(variant, method, route); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *node) check(tokens []token, method string, route *Route) error {
+	cur := n
+	for _, t := range tokens {
+		switch t.kind {
+		case staticKind:
+			cur = cur.findStatic(t.text)
+		case paramKind:
+			var next *node
+			for _, p := range cur.paramChildren {
+				if p.spec == t.spec {
+					next = p
+					break
+				}
+			}
+			if next != nil && next.prefix != t.text {
+				if existing := next.anyRoute(); existing != nil && existing != route {
+					return &ConflictError{
+						Method:   method,
+						Route:    route,
+						Existing: existing,
+						Reason:   fmt.Sprintf("parameter %q conflicts with %q at the same position", ":"+t.text, ":"+next.prefix),
+					}
+				}
+			}
+			cur = next
+		case wildcardKind:
+			next := cur.wildChild
+			if next != nil && next.prefix != t.text {
+				if existing := next.anyRoute(); existing != nil && existing != route {
+					return &ConflictError{
+						Method:   method,
+						Route:    route,
+						Existing: existing,
+						Reason:   fmt.Sprintf("wildcard %q conflicts with %q at the same position", "*"+t.text, "*"+next.prefix),
+					}
+				}
+			}
+			cur = next
+		}
+		if cur == nil {
+			return nil
+		}
+	}
+
+	if cur.handler != nil && cur.route != route {
+		reason := "duplicate route"
+		if cur.route != nil && cur.route.Path != route.Path {
+			reason = "patterns match the same paths"
+		}
+		return &ConflictError{Method: method, Route: route, Existing: cur.route, Reason: reason}
+	}
+	return nil
+}
+
+func (n *node) findStatic(text string) *node {
+	cur := n
+	for len(text) > 0 {
+		idx := strings.IndexByte(cur.indices, text[0])
+		if idx < 0 {
+			return nil
+		}
+		child := cur.children[idx]
+		if len(text) < len(child.prefix) || text[:len(child.prefix)] != child.prefix {
+			return nil
+		}
+		text = text[len(child.prefix):]
+		cur = child
+	}
+	return cur
+}
+
+// anyRoute returns a route registered at or below n.
+func (n *node) anyRoute() *Route {
+	if n.route != nil {
+		return n.route
+	}
+	for _, c := range n.children {
+		if r := c.anyRoute(); r != nil {
+			return r
+		}
+	}
+	for _, c := range n.paramChildren {
+		if r := c.anyRoute(); r != nil {
+			return r
+		}
+	}
+	if n.wildChild != nil {
+		return n.wildChild.anyRoute()
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package router
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ===== PARAMETER CONSTRAINTS =====
+
+// constraint validates a single captured segment, e.g. ":id<int>".
+type constraint func(value string) bool
+
+// parseConstraints compiles a constraint list such as "int;min(1)" or
+// "regex(^[a-z]+\.txt$)". Constraints are separated by ';' outside parentheses.
+func parseConstraints(spec string) ([]constraint, error) {
+	parts := splitTopLevel(spec, ';')
+	out := make([]constraint, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		c, err := parseConstraint(part)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+func parseConstraint(part string) (constraint, error) {
+	name, arg := part, ""
+	if open := strings.IndexByte(part, '('); open >= 0 {
+		if !strings.HasSuffix(part, ")") {
+			return nil, fmt.Errorf("constraint %q: missing closing parenthesis", part)
+		}
+		name, arg = part[:open], part[open+1:len(part)-1]
+	}
+
+	switch name {
+	case "int":
+		return func(v string) bool {
+			_, err := strconv.ParseInt(v, 10, 64)
+			return err == nil
+		}, nil
+	case "float":
+		return func(v string) bool {
+			_, err := strconv.ParseFloat(v, 64)
+			return err == nil
+		}, nil
+	case "bool":
+		return func(v string) bool {
+			_, err := strconv.ParseBool(v)
+			return err == nil
+		}, nil
+	case "alpha":
+		return func(v string) bool { return allBytes(v, isAlpha) }, nil
+	case "alnum":
+		return func(v string) bool {
+			return allBytes(v, func(b byte) bool { return isAlpha(b) || isDigit(b) })
+		}, nil
+	case "uuid":
+		return isUUID, nil
+	case "minlen", "maxlen", "len":
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("constraint %q: invalid length %q", part, arg)
+		}
+		switch name {
+		case "minlen":
+			return func(v string) bool { return utf8.RuneCountInString(v) >= n }, nil
+		case "maxlen":
+			return func(v string) bool { return utf8.RuneCountInString(v) <= n }, nil
+		default:
+			return func(v string) bool { return utf8.RuneCountInString(v) == n }, nil
+		}
+	case "min", "max":
+		n, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("constraint %q: invalid number %q", part, arg)
+		}
+		return func(v string) bool {
+			i, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return false
+			}
+			if name == "min" {
+				return i >= n
+			}
+			return i <= n
+		}, nil
+	case "range":
+		bounds := strings.Split(arg, ",")
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("constraint %q: expected range(min,max)", part)
+		}
+		lo, err1 := strconv.ParseInt(strings.TrimSpace(bounds[0]), 10, 64)
+		hi, err2 := strconv.ParseInt(strings.TrimSpace(bounds[1]), 10, 64)
+		if err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("constraint %q: invalid bounds", part)
+		}
+		return func(v string) bool {
+			i, err := strconv.ParseInt(v, 10, 64)
+			return err == nil && i >= lo && i <= hi
+		}, nil
+	case "regex":
+		re, err := regexp.Compile(arg)
+		if err != nil {
+			return nil, fmt.Errorf("constraint %q: %v", part, err)
+		}
+		return re.MatchString, nil
+	}
+	return nil, fmt.Errorf("unknown constraint %q", part)
+}
+
+// splitTopLevel splits s on sep, ignoring separators inside parentheses.
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func isUUID(v string) bool {
+	if len(v) != 36 {
+		return false
+	}
+	for i := 0; i < len(v); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if v[i] != '-' {
+				return false
+			}
+		default:
+			if !isHex(v[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func allBytes(v string, fn func(byte) bool) bool {
+	for i := 0; i < len(v); i++ {
+		if !fn(v[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlpha(b byte) bool { return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') }
+func isDigit(b byte) bool { return b >= '0' && b <= '9' }
+func isHex(b byte) bool   { return isDigit(b) || (b >= 'a' && b <= 'f') || (b >= 'A' && b <= 'F') }
@@ -1,8 +1,9 @@
 package router
 
 import (
-	"strings"
-	"sync"
+	"fmt"
+	"path"
+	"sort"
 	"time"
 
 	"github.com/Dziqha/TurboGo/core"
@@ -13,32 +14,27 @@ import (
 // Reduced memory footprint by removing unused fields
 type Route struct {
 	Method   string
+	Methods  []string // every method the route was registered for
 	Path     string
+	Host     string // host pattern for host-scoped routes, empty for the default table
 	Name     string
 	Handlers []core.Handler
+	Names    []string // function names of Handlers as registered, for listings
 	Options  RouteOptions
-}
+	Metadata map[string]any
+	Source   string // file:line of the registration, used in conflict errors
 
-type RouteOptions struct {
-	Ttl     *time.Duration
-	Disable bool
-	Force   bool
+	router *SingleThreadedRouter
 }
 
-// More memory-efficient route structures
-type WildcardRoute struct {
-	prefix  string
-	handler core.Handler
-	route   *Route
-	pLen    uint8 // Changed from int to uint8 (saves 7 bytes per route)
-}
+type RouteOptions struct {
+	Ttl     *time.Duration // lifetime of cached responses, see Route.Cache
+	Disable bool           // response caching is off
+	Force   bool           // serve cached responses even to "Cache-Control: no-cache" requests
+	Vary    []string       // request headers that are part of the cache key
 
-type ParametricRoute struct {
-	pattern  string
-	handler  core.Handler
-	route    *Route
-	params   []string
-	segments []string
+	NoAutoHead    bool // don't answer HEAD with this GET route
+	NoAutoOptions bool // don't answer OPTIONS automatically on this path
 }
 
 // Removed unused Connection struct
@@ -52,64 +48,55 @@ type RouterConfig struct {
 	StaticPreAlloc     int
 	WildcardPreAlloc   int
 	ParametricPreAlloc int
+
+	// Path policies, applied by the app before and after Find.
+	TrailingSlash   TrailingSlashPolicy
+	CaseInsensitive bool // match static segments ignoring case
+	CleanPath       bool // redirect "//a/./b/../c" style paths to their canonical form
 }
 
-type FastRoute struct {
-	handler core.Handler
-	route   *Route
+// TrailingSlashPolicy decides how "/users/" is treated when only "/users"
+// is registered, and vice versa.
+type TrailingSlashPolicy uint8
+
+const (
+	TrailingSlashStrict   TrailingSlashPolicy = iota // the two paths are distinct
+	TrailingSlashRedirect                            // redirect to the registered variant
+	TrailingSlashMatch                               // serve the registered variant directly
+)
+
+// methodTree holds the radix tree for a single HTTP method.
+type methodTree struct {
+	method string
+	root   *node
 }
 
 type SingleThreadedRouter struct {
-	// Reduced initial map sizes to prevent over-allocation
-	staticGET    map[string]*FastRoute
-	staticPOST   map[string]*FastRoute
-	staticPUT    map[string]*FastRoute
-	staticDELETE map[string]*FastRoute
-	staticOther  map[string]*FastRoute
-
-	// Use arrays instead of slices for better memory locality
-	wildcardGET    []WildcardRoute
-	wildcardPOST   []WildcardRoute
-	wildcardPUT    []WildcardRoute
-	wildcardDELETE []WildcardRoute
-	wildcardOther  []WildcardRoute
-
-	parametricGET    []ParametricRoute
-	parametricPOST   []ParametricRoute
-	parametricPUT    []ParametricRoute
-	parametricDELETE []ParametricRoute
-	parametricOther  []ParametricRoute
-
-	// Removed unused pools and buffers
-	notFoundHandler core.Handler
-	config          RouterConfig
+	// One tree per method; a slice scan beats a map lookup for the handful
+	// of methods a real app registers.
+	trees []methodTree
+
+	names map[string]*Route
+
+	staticCount     int
+	wildcardCount   int
+	parametricCount int
+
+	notFoundHandler         core.Handler
+	methodNotAllowedHandler core.Handler
+	config                  RouterConfig
 }
 
 func NewSingleThreadedRouter() *SingleThreadedRouter {
 	return &SingleThreadedRouter{
-		// Start with minimal sizes and let Go grow as needed
-		staticGET:    make(map[string]*FastRoute, 8),
-		staticPOST:   make(map[string]*FastRoute, 4),
-		staticPUT:    make(map[string]*FastRoute, 2),
-		staticDELETE: make(map[string]*FastRoute, 2),
-		staticOther:  make(map[string]*FastRoute, 2),
-
-		// Minimal initial capacity
-		wildcardGET:    make([]WildcardRoute, 0, 2),
-		wildcardPOST:   make([]WildcardRoute, 0, 1),
-		wildcardPUT:    make([]WildcardRoute, 0, 1),
-		wildcardDELETE: make([]WildcardRoute, 0, 1),
-		wildcardOther:  make([]WildcardRoute, 0, 1),
-
-		parametricGET:    make([]ParametricRoute, 0, 4),
-		parametricPOST:   make([]ParametricRoute, 0, 2),
-		parametricPUT:    make([]ParametricRoute, 0, 2),
-		parametricDELETE: make([]ParametricRoute, 0, 2),
-		parametricOther:  make([]ParametricRoute, 0, 2),
-
+		trees: make([]methodTree, 0, 4),
+		names: make(map[string]*Route),
 		notFoundHandler: func(c *core.Context) {
 			c.Status(404).SendString("404 Not Found")
 		},
+		methodNotAllowedHandler: func(c *core.Context) {
+			c.Status(405).SendString("405 Method Not Allowed")
+		},
 		config: DefaultRouterConfig(),
 	}
 }
@@ -128,265 +115,231 @@ func DefaultRouterConfig() RouterConfig {
 	}
 }
 
-// Optimized prefix matching without unsafe operations for better stability
-func quickPrefix(s, prefix string, pLen int) bool {
-	if len(s) < pLen {
-		return false
+func (r *SingleThreadedRouter) tree(method string) *node {
+	for i := range r.trees {
+		if r.trees[i].method == method {
+			return r.trees[i].root
+		}
 	}
-	return s[:pLen] == prefix
-}
-
-// Memory-efficient parametric matching using sync.Pool for parameter maps
-var paramMapPool = sync.Pool{
-	New: func() interface{} {
-		return make(map[string]string, 4)
-	},
+	return nil
 }
 
-func (r *SingleThreadedRouter) matchParametric(param *ParametricRoute, path string) (bool, map[string]string) {
-	pathSegments := strings.Split(path, "/")
-
-	if len(pathSegments) != len(param.segments) {
-		return false, nil
+func (r *SingleThreadedRouter) treeFor(method string) *node {
+	if root := r.tree(method); root != nil {
+		return root
 	}
+	root := &node{kind: staticKind}
+	r.trees = append(r.trees, methodTree{method: method, root: root})
+	return root
+}
 
-	// Get from pool instead of allocating
-	paramMap := paramMapPool.Get().(map[string]string)
-
-	// Clear the map
-	for k := range paramMap {
-		delete(paramMap, k)
-	}
+// ===== ROUTE REGISTRATION =====
 
-	paramIdx := 0
-	for i, segment := range param.segments {
-		if len(segment) > 0 && segment[0] == ':' {
-			if paramIdx < len(param.params) {
-				paramMap[param.params[paramIdx]] = pathSegments[i]
-				paramIdx++
-			}
-		} else if segment != pathSegments[i] {
-			// Return to pool before returning false
-			paramMapPool.Put(paramMap)
-			return false, nil
+// Add registers pattern, choosing between a static, parametric or wildcard
+// route from its syntax. "*name" captures the rest of the path under name
+// ("*" alone captures it under "*") and may follow ":param" segments, e.g.
+// "/repos/:owner/*path".
+func (r *SingleThreadedRouter) Add(method, pattern string, handler core.Handler, route *Route) {
+	tokens := mustTokenize(pattern)
+	r.attach(route)
+	r.insertVariants(method, tokens, handler, route)
+
+	kind := staticKind
+	for _, t := range tokens {
+		if t.kind > kind {
+			kind = t.kind
 		}
 	}
-
-	if len(paramMap) == 0 {
-		paramMapPool.Put(paramMap)
-		return true, nil
-	}
-
-	// Create result map and return the pooled one
-	result := make(map[string]string, len(paramMap))
-	for k, v := range paramMap {
-		result[k] = v
+	switch kind {
+	case staticKind:
+		r.staticCount++
+	case paramKind:
+		r.parametricCount++
+	default:
+		r.wildcardCount++
 	}
-	paramMapPool.Put(paramMap)
-
-	return true, result
 }
 
-// ===== ROUTE REGISTRATION =====
-
+// AddStatic registers path literally; ':' and '*' carry no special meaning.
 func (r *SingleThreadedRouter) AddStatic(method, path string, handler core.Handler, route *Route) {
-	fastRoute := &FastRoute{
-		handler: handler,
-		route:   route,
-	}
-
-	switch method {
-	case "GET":
-		r.staticGET[path] = fastRoute
-	case "POST":
-		r.staticPOST[path] = fastRoute
-	case "PUT":
-		r.staticPUT[path] = fastRoute
-	case "DELETE":
-		r.staticDELETE[path] = fastRoute
-	default:
-		r.staticOther[method+":"+path] = fastRoute
-	}
+	r.attach(route)
+	r.treeFor(method).insert([]token{{kind: staticKind, text: path}}, handler, route)
+	r.staticCount++
 }
 
+// AddWildcard registers a catch-all matching every path that starts with prefix.
 func (r *SingleThreadedRouter) AddWildcard(method, prefix string, handler core.Handler, route *Route) {
-	wildRoute := WildcardRoute{
-		prefix:  prefix,
-		handler: handler,
-		route:   route,
-		pLen:    uint8(len(prefix)), // Use uint8 instead of int
-	}
-
-	switch method {
-	case "GET":
-		r.wildcardGET = append(r.wildcardGET, wildRoute)
-	case "POST":
-		r.wildcardPOST = append(r.wildcardPOST, wildRoute)
-	case "PUT":
-		r.wildcardPUT = append(r.wildcardPUT, wildRoute)
-	case "DELETE":
-		r.wildcardDELETE = append(r.wildcardDELETE, wildRoute)
-	default:
-		r.wildcardOther = append(r.wildcardOther, wildRoute)
-	}
+	r.attach(route)
+	tokens := append(mustTokenize(prefix), token{kind: wildcardKind})
+	r.insertVariants(method, tokens, handler, route)
+	r.wildcardCount++
 }
 
+// AddParametric registers a pattern containing ":name" segments. A segment
+// may be constrained (":id<int>", ":name<regex(^[a-z]+$)>", ":slug<minlen(3)>")
+// and marked optional (":page?"). Values failing a constraint fall through
+// to the next candidate route.
 func (r *SingleThreadedRouter) AddParametric(method, pattern string, handler core.Handler, route *Route) {
-	segments := strings.Split(pattern, "/")
-	params := make([]string, 0, 2) // Pre-allocate with small capacity
-
-	for _, segment := range segments {
-		if len(segment) > 0 && segment[0] == ':' {
-			params = append(params, segment[1:])
-		}
-	}
+	r.attach(route)
+	r.insertVariants(method, mustTokenize(pattern), handler, route)
+	r.parametricCount++
+}
 
-	paramRoute := ParametricRoute{
-		pattern:  pattern,
-		handler:  handler,
-		route:    route,
-		params:   params,
-		segments: segments,
+func (r *SingleThreadedRouter) insertVariants(method string, tokens []token, handler core.Handler, route *Route) {
+	root := r.treeFor(method)
+	for _, variant := range expandOptional(tokens) {
+		root.insert(variant, handler, route)
 	}
+}
 
-	switch method {
-	case "GET":
-		r.parametricGET = append(r.parametricGET, paramRoute)
-	case "POST":
-		r.parametricPOST = append(r.parametricPOST, paramRoute)
-	case "PUT":
-		r.parametricPUT = append(r.parametricPUT, paramRoute)
-	case "DELETE":
-		r.parametricDELETE = append(r.parametricDELETE, paramRoute)
-	default:
-		r.parametricOther = append(r.parametricOther, paramRoute)
+func mustTokenize(pattern string) []token {
+	tokens, err := tokenize(pattern)
+	if err != nil {
+		panic(fmt.Sprintf("invalid route pattern %q: %v", pattern, err))
 	}
+	return tokens
 }
 
-// ===== OPTIMIZED ROUTE LOOKUP =====
-
-func (r *SingleThreadedRouter) Find(method, path string) (core.Handler, *Route, map[string]string) {
-	// GET optimization (most common case first)
-	if method == "GET" {
-		if route := r.staticGET[path]; route != nil {
-			return route.handler, route.route, nil
-		}
+// ===== ROUTE LOOKUP =====
 
-		// Wildcard routes
-		for i := range r.wildcardGET {
-			wild := &r.wildcardGET[i]
-			if len(path) >= int(wild.pLen) && quickPrefix(path, wild.prefix, int(wild.pLen)) {
-				return wild.handler, wild.route, nil
-			}
+// Find resolves method and path to a handler. Captured parameters are
+// appended to ps; with a buffer from AcquireParams the lookup does not allocate.
+func (r *SingleThreadedRouter) Find(method, path string, ps *Params) (core.Handler, *Route) {
+	if ps == nil {
+		ps = &Params{}
+	}
+	if root := r.tree(method); root != nil {
+		if n := root.lookup(path, ps, false); n != nil {
+			return n.handler, n.route
 		}
-
-		// Parametric routes
-		for i := range r.parametricGET {
-			param := &r.parametricGET[i]
-			if matched, params := r.matchParametric(param, path); matched {
-				return param.handler, param.route, params
+		if r.config.CaseInsensitive {
+			if n := root.lookup(path, ps, true); n != nil {
+				return n.handler, n.route
 			}
 		}
-
-		return r.notFoundHandler, nil, nil
 	}
+	return r.notFoundHandler, nil
+}
 
-	// Handle other methods efficiently
-	var staticMap map[string]*FastRoute
-	var wildcards []WildcardRoute
-	var params []ParametricRoute
-
-	switch method {
-	case "POST":
-		staticMap, wildcards, params = r.staticPOST, r.wildcardPOST, r.parametricPOST
-	case "PUT":
-		staticMap, wildcards, params = r.staticPUT, r.wildcardPUT, r.parametricPUT
-	case "DELETE":
-		staticMap, wildcards, params = r.staticDELETE, r.wildcardDELETE, r.parametricDELETE
-	default:
-		staticMap, wildcards, params = r.staticOther, r.wildcardOther, r.parametricOther
-		// Try method-prefixed lookup for other methods
-		if route := r.staticOther[method+":"+path]; route != nil {
-			return route.handler, route.route, nil
+// Allowed lists, in sorted order, every method other than method whose tree
+// matches path. An empty result means the path is unknown to the router.
+func (r *SingleThreadedRouter) Allowed(method, path string) []string {
+	var allowed []string
+	var ps Params
+	for i := range r.trees {
+		t := &r.trees[i]
+		if t.method == method {
+			continue
+		}
+		ps = ps[:0]
+		if t.root.lookup(path, &ps, r.config.CaseInsensitive) != nil {
+			allowed = append(allowed, t.method)
 		}
 	}
+	sort.Strings(allowed)
+	return allowed
+}
 
-	// Static lookup
-	if route := staticMap[path]; route != nil {
-		return route.handler, route.route, nil
-	}
+func (r *SingleThreadedRouter) Config() RouterConfig {
+	return r.config
+}
 
-	// Wildcard lookup
-	for i := range wildcards {
-		wild := &wildcards[i]
-		if len(path) >= int(wild.pLen) && quickPrefix(path, wild.prefix, int(wild.pLen)) {
-			return wild.handler, wild.route, nil
-		}
-	}
+func (r *SingleThreadedRouter) SetConfig(config RouterConfig) {
+	r.config = config
+}
 
-	// Parametric lookup
-	for i := range params {
-		param := &params[i]
-		if matched, paramMap := r.matchParametric(param, path); matched {
-			return param.handler, param.route, paramMap
-		}
+// CleanPath returns the canonical form of p: a single leading slash, no
+// repeated slashes, "." and ".." segments resolved and the trailing slash kept.
+func CleanPath(p string) string {
+	if p == "" {
+		return "/"
+	}
+	cleaned := path.Clean("/" + p)
+	if p[len(p)-1] == '/' && cleaned != "/" {
+		cleaned += "/"
 	}
+	return cleaned
+}
+
+func (r *SingleThreadedRouter) SetNotFoundHandler(h core.Handler) {
+	r.notFoundHandler = h
+}
 
-	return r.notFoundHandler, nil, nil
+func (r *SingleThreadedRouter) NotFoundHandler() core.Handler {
+	return r.notFoundHandler
+}
+
+func (r *SingleThreadedRouter) SetMethodNotAllowedHandler(h core.Handler) {
+	r.methodNotAllowedHandler = h
+}
+
+func (r *SingleThreadedRouter) MethodNotAllowedHandler() core.Handler {
+	return r.methodNotAllowedHandler
 }
 
 // ===== UTILITY METHODS =====
 
 func (r *SingleThreadedRouter) Reset() {
-	// More efficient map clearing
-	r.staticGET = make(map[string]*FastRoute, 8)
-	r.staticPOST = make(map[string]*FastRoute, 4)
-	r.staticPUT = make(map[string]*FastRoute, 2)
-	r.staticDELETE = make(map[string]*FastRoute, 2)
-	r.staticOther = make(map[string]*FastRoute, 2)
-
-	// Reset slices to zero length but keep capacity
-	r.wildcardGET = r.wildcardGET[:0]
-	r.wildcardPOST = r.wildcardPOST[:0]
-	r.wildcardPUT = r.wildcardPUT[:0]
-	r.wildcardDELETE = r.wildcardDELETE[:0]
-	r.wildcardOther = r.wildcardOther[:0]
-
-	r.parametricGET = r.parametricGET[:0]
-	r.parametricPOST = r.parametricPOST[:0]
-	r.parametricPUT = r.parametricPUT[:0]
-	r.parametricDELETE = r.parametricDELETE[:0]
-	r.parametricOther = r.parametricOther[:0]
+	r.trees = r.trees[:0]
+	r.names = make(map[string]*Route)
+	r.staticCount = 0
+	r.wildcardCount = 0
+	r.parametricCount = 0
 }
 
 func (r *SingleThreadedRouter) GetRouteCount() (static, wildcard, parametric int) {
-	static = len(r.staticGET) + len(r.staticPOST) + len(r.staticPUT) + len(r.staticDELETE) + len(r.staticOther)
-	wildcard = len(r.wildcardGET) + len(r.wildcardPOST) + len(r.wildcardPUT) + len(r.wildcardDELETE) + len(r.wildcardOther)
-	parametric = len(r.parametricGET) + len(r.parametricPOST) + len(r.parametricPUT) + len(r.parametricDELETE) + len(r.parametricOther)
-	return
+	return r.staticCount, r.wildcardCount, r.parametricCount
 }
 
 // Simplified warm-up without unnecessary allocations
 func (r *SingleThreadedRouter) WarmUp(routes []struct{ Method, Path string }) {
+	ps := AcquireParams()
+	defer ReleaseParams(ps)
 	for _, route := range routes {
-		r.Find(route.Method, route.Path)
+		*ps = (*ps)[:0]
+		r.Find(route.Method, route.Path, ps)
 	}
 }
 
-// // Route configuration methods
-// func (r *Route) NoCache() *Route {
-// 	r.Options.Disable = true
-// 	return r
-// }
+// Meta attaches a metadata entry to the route, reported by route introspection.
+func (r *Route) Meta(key string, value any) *Route {
+	if r.Metadata == nil {
+		r.Metadata = make(map[string]any)
+	}
+	r.Metadata[key] = value
+	return r
+}
 
-// func (r *Route) Cache(ttl time.Duration) *Route {
-// 	r.Options.Ttl = &ttl
-// 	r.Options.Disable = false
-// 	r.Options.Force = true
-// 	return r
-// }
+// NoAutoHead stops HEAD requests from being served by this GET route.
+func (r *Route) NoAutoHead() *Route {
+	r.Options.NoAutoHead = true
+	return r
+}
 
-// func (r *Route) Named(name string) *Route {
-// 	r.Name = name
-// 	return r
-// }
+// NoAutoOptions stops OPTIONS requests on this route's path from being
+// answered automatically.
+func (r *Route) NoAutoOptions() *Route {
+	r.Options.NoAutoOptions = true
+	return r
+}
+
+// Cache stores the route's GET responses in the app's cache engine for ttl.
+// It has no effect unless the app was created with WithCache.
+func (r *Route) Cache(ttl time.Duration) *Route {
+	r.Options.Ttl = &ttl
+	r.Options.Disable = false
+	return r
+}
+
+// NoCache turns response caching off for the route.
+func (r *Route) NoCache() *Route {
+	r.Options.Disable = true
+	return r
+}
+
+// Vary adds request headers whose values select separate cache entries,
+// e.g. Vary("Accept-Language").
+func (r *Route) Vary(headers ...string) *Route {
+	r.Options.Vary = append(r.Options.Vary, headers...)
+	return r
+}
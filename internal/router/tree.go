@@ -0,0 +1,422 @@
+package router
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Dziqha/TurboGo/core"
+)
+
+// ===== RADIX TREE =====
+
+type nodeKind uint8
+
+const (
+	staticKind nodeKind = iota
+	paramKind
+	wildcardKind
+)
+
+// Param is a single captured route parameter. Key and Value point into the
+// registered pattern and the request path, so collecting them never allocates.
+type Param struct {
+	Key   string
+	Value string
+}
+
+// Params is the ordered list of parameters captured while matching a path.
+type Params []Param
+
+// Get returns the value of the first parameter with the given name.
+func (ps Params) Get(name string) (string, bool) {
+	for i := range ps {
+		if ps[i].Key == name {
+			return ps[i].Value, true
+		}
+	}
+	return "", false
+}
+
+var paramsPool = sync.Pool{
+	New: func() interface{} {
+		ps := make(Params, 0, 8)
+		return &ps
+	},
+}
+
+// AcquireParams returns an empty Params buffer from the pool.
+func AcquireParams() *Params {
+	ps := paramsPool.Get().(*Params)
+	*ps = (*ps)[:0]
+	return ps
+}
+
+// ReleaseParams returns a buffer obtained from AcquireParams to the pool.
+func ReleaseParams(ps *Params) {
+	if ps == nil {
+		return
+	}
+	*ps = (*ps)[:0]
+	paramsPool.Put(ps)
+}
+
+// node is a compressed radix tree node. Static children are indexed by the
+// first byte of their prefix and kept ordered by priority, so the busiest
+// branches are checked first; param and wildcard children are tried after
+// the static child, giving static > param > wildcard precedence.
+type node struct {
+	kind     nodeKind
+	prefix   string // static label, or the parameter name for param/wildcard nodes
+	indices  string // first byte of every static child, same order as children
+	children []*node
+
+	paramChildren []*node
+	wildChild     *node
+
+	spec        string // constraint list of a param node, part of its identity
+	constraints []constraint
+
+	handler  core.Handler
+	route    *Route
+	priority uint32
+}
+
+type token struct {
+	kind     nodeKind
+	text     string
+	spec     string // raw constraint list of a param, e.g. "int;min(1)"
+	optional bool
+}
+
+// tokenize splits a route pattern into static, ":param" and "*wildcard" parts.
+// Parameters and wildcards start a segment; a ':' or '*' anywhere else is
+// literal text, so "/v1/items:batchGet" is a static route. A parameter runs
+// until the next '/' and may carry constraints in angle brackets
+// (":id<int>") and a trailing '?' marking it optional. A wildcard captures
+// the rest of the path and must come last.
+func tokenize(pattern string) ([]token, error) {
+	tokens := make([]token, 0, 4)
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case ':':
+			t, rest, err := parseParam(pattern[1:])
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, t)
+			pattern = rest
+		case '*':
+			name := pattern[1:]
+			if strings.ContainsAny(name, "/:*") {
+				return nil, fmt.Errorf("wildcard %q must be the last segment", pattern)
+			}
+			tokens = append(tokens, token{kind: wildcardKind, text: name})
+			pattern = ""
+		default:
+			end := segmentToken(pattern)
+			tokens = append(tokens, token{kind: staticKind, text: pattern[:end]})
+			pattern = pattern[end:]
+		}
+	}
+	return tokens, nil
+}
+
+// segmentToken returns the index of the first ':' or '*' of s that follows a
+// '/', or len(s).
+func segmentToken(s string) int {
+	for i := 1; i < len(s); i++ {
+		if (s[i] == ':' || s[i] == '*') && s[i-1] == '/' {
+			return i
+		}
+	}
+	return len(s)
+}
+
+func parseParam(s string) (token, string, error) {
+	end := strings.IndexAny(s, "/<?")
+	if end < 0 {
+		end = len(s)
+	}
+	t := token{kind: paramKind, text: s[:end]}
+	if t.text == "" {
+		return t, "", fmt.Errorf("empty parameter name")
+	}
+	s = s[end:]
+
+	if len(s) > 0 && s[0] == '<' {
+		close := matchingAngle(s)
+		if close < 0 {
+			return t, "", fmt.Errorf("parameter %q: unclosed constraint", t.text)
+		}
+		t.spec = s[1:close]
+		s = s[close+1:]
+	}
+	if len(s) > 0 && s[0] == '?' {
+		t.optional = true
+		s = s[1:]
+	}
+	if len(s) > 0 && s[0] != '/' {
+		return t, "", fmt.Errorf("parameter %q: unexpected %q after parameter", t.text, s)
+	}
+	return t, s, nil
+}
+
+// matchingAngle returns the index of the '>' closing s[0], skipping anything
+// inside parentheses so regular expressions may contain '>' or '/'.
+func matchingAngle(s string) int {
+	depth := 0
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '>':
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// expandOptional returns every variant of tokens with optional params either
+// present or dropped together with the '/' in front of them.
+func expandOptional(tokens []token) [][]token {
+	variants := [][]token{nil}
+	for _, t := range tokens {
+		if t.kind != paramKind || !t.optional {
+			for i := range variants {
+				variants[i] = append(variants[i], t)
+			}
+			continue
+		}
+
+		next := make([][]token, 0, len(variants)*2)
+		for _, v := range variants {
+			with := append(append(make([]token, 0, len(v)+1), v...), t)
+			without := append(make([]token, 0, len(v)), v...)
+			if n := len(without); n > 0 && without[n-1].kind == staticKind {
+				last := without[n-1]
+				last.text = strings.TrimSuffix(last.text, "/")
+				without[n-1] = last
+			}
+			next = append(next, with, without)
+		}
+		variants = next
+	}
+
+	for i, v := range variants {
+		if len(v) == 0 || (len(v) == 1 && v[0].kind == staticKind && v[0].text == "") {
+			variants[i] = []token{{kind: staticKind, text: "/"}}
+		}
+	}
+	return variants
+}
+
+// insert registers handler under the given tokens and returns the leaf node.
+func (n *node) insert(tokens []token, handler core.Handler, route *Route) *node {
+	path := []*node{n}
+	cur := n
+	for _, t := range tokens {
+		switch t.kind {
+		case staticKind:
+			cur = cur.addStatic(t.text, &path)
+		case paramKind:
+			cur = cur.addParam(t)
+			path = append(path, cur)
+		case wildcardKind:
+			if cur.wildChild == nil {
+				cur.wildChild = &node{kind: wildcardKind, prefix: t.text}
+			}
+			// A wildcard ends the pattern, so a differently named one
+			// replaces the existing route and takes over the node.
+			cur.wildChild.prefix = t.text
+			cur = cur.wildChild
+			path = append(path, cur)
+		}
+	}
+
+	cur.handler = handler
+	cur.route = route
+
+	for _, p := range path {
+		p.priority++
+	}
+	for _, p := range path {
+		p.sortChildren()
+	}
+	return cur
+}
+
+func (n *node) addStatic(text string, path *[]*node) *node {
+	cur := n
+	for len(text) > 0 {
+		idx := strings.IndexByte(cur.indices, text[0])
+		if idx < 0 {
+			child := &node{kind: staticKind, prefix: text}
+			cur.indices += string(text[0])
+			cur.children = append(cur.children, child)
+			*path = append(*path, child)
+			return child
+		}
+
+		child := cur.children[idx]
+		l := longestCommonPrefix(text, child.prefix)
+		if l < len(child.prefix) {
+			child.split(l)
+		}
+		*path = append(*path, child)
+		text = text[l:]
+		cur = child
+	}
+	return cur
+}
+
+// split moves everything below position l of n's prefix into a new child.
+func (n *node) split(l int) {
+	rest := &node{
+		kind:          staticKind,
+		prefix:        n.prefix[l:],
+		indices:       n.indices,
+		children:      n.children,
+		paramChildren: n.paramChildren,
+		wildChild:     n.wildChild,
+		handler:       n.handler,
+		route:         n.route,
+		priority:      n.priority,
+	}
+	n.prefix = n.prefix[:l]
+	n.indices = string(rest.prefix[0])
+	n.children = []*node{rest}
+	n.paramChildren = nil
+	n.wildChild = nil
+	n.handler = nil
+	n.route = nil
+}
+
+func (n *node) addParam(t token) *node {
+	for _, p := range n.paramChildren {
+		if p.prefix == t.text && p.spec == t.spec {
+			return p
+		}
+	}
+	constraints, err := parseConstraints(t.spec)
+	if err != nil {
+		panic(fmt.Sprintf("parameter %q: %v", t.text, err))
+	}
+	child := &node{kind: paramKind, prefix: t.text, spec: t.spec, constraints: constraints}
+	n.paramChildren = append(n.paramChildren, child)
+
+	// Constrained params are more specific, so they are tried first.
+	sort.SliceStable(n.paramChildren, func(i, j int) bool {
+		return len(n.paramChildren[i].constraints) > 0 && len(n.paramChildren[j].constraints) == 0
+	})
+	return child
+}
+
+func (n *node) accepts(value string) bool {
+	for _, c := range n.constraints {
+		if !c(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *node) sortChildren() {
+	if len(n.children) < 2 {
+		return
+	}
+	sort.SliceStable(n.children, func(i, j int) bool {
+		return n.children[i].priority > n.children[j].priority
+	})
+	var b strings.Builder
+	b.Grow(len(n.children))
+	for _, c := range n.children {
+		b.WriteByte(c.prefix[0])
+	}
+	n.indices = b.String()
+}
+
+// lookup matches the remaining path below n, appending captured params to ps.
+// With fold set, static segments are compared case-insensitively.
+// On failure ps is restored to its original length.
+func (n *node) lookup(path string, ps *Params, fold bool) *node {
+	if len(path) == 0 {
+		if n.handler != nil {
+			return n
+		}
+		return n.matchWildcard(path, ps)
+	}
+
+	if !fold {
+		if idx := strings.IndexByte(n.indices, path[0]); idx >= 0 {
+			child := n.children[idx]
+			if len(path) >= len(child.prefix) && path[:len(child.prefix)] == child.prefix {
+				if res := child.lookup(path[len(child.prefix):], ps, fold); res != nil {
+					return res
+				}
+			}
+		}
+	} else {
+		for _, child := range n.children {
+			if len(path) >= len(child.prefix) && strings.EqualFold(path[:len(child.prefix)], child.prefix) {
+				if res := child.lookup(path[len(child.prefix):], ps, fold); res != nil {
+					return res
+				}
+			}
+		}
+	}
+
+	if len(n.paramChildren) > 0 {
+		end := strings.IndexByte(path, '/')
+		if end < 0 {
+			end = len(path)
+		}
+		if end > 0 {
+			mark := len(*ps)
+			for _, p := range n.paramChildren {
+				if !p.accepts(path[:end]) {
+					continue
+				}
+				*ps = append(*ps, Param{Key: p.prefix, Value: path[:end]})
+				if res := p.lookup(path[end:], ps, fold); res != nil {
+					return res
+				}
+				*ps = (*ps)[:mark]
+			}
+		}
+	}
+
+	return n.matchWildcard(path, ps)
+}
+
+func (n *node) matchWildcard(path string, ps *Params) *node {
+	w := n.wildChild
+	if w == nil || w.handler == nil {
+		return nil
+	}
+	key := w.prefix
+	if key == "" {
+		key = wildcardKey
+	}
+	*ps = append(*ps, Param{Key: key, Value: path})
+	return w
+}
+
+func longestCommonPrefix(a, b string) int {
+	max := len(a)
+	if len(b) < max {
+		max = len(b)
+	}
+	i := 0
+	for i < max && a[i] == b[i] {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,109 @@
+package router
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ===== NAMED ROUTES =====
+
+// wildcardKey is the parameter name used for an unnamed "*" wildcard.
+const wildcardKey = "*"
+
+func (r *SingleThreadedRouter) attach(route *Route) {
+	if route == nil {
+		return
+	}
+	route.router = r
+	if route.Name != "" {
+		r.nameRoute(route, route.Name)
+	}
+}
+
+func (r *SingleThreadedRouter) nameRoute(route *Route, name string) {
+	if existing, ok := r.names[name]; ok && existing != route {
+		panic(fmt.Sprintf("route name %q already used by %s %s", name, existing.Method, existing.Path))
+	}
+	if route.Name != "" && r.names[route.Name] == route {
+		delete(r.names, route.Name)
+	}
+	r.names[name] = route
+}
+
+// NamedRoute returns the route registered under name, or nil.
+func (r *SingleThreadedRouter) NamedRoute(name string) *Route {
+	return r.names[name]
+}
+
+// URL builds the path of the route registered under name. Every ":param" in
+// the pattern must be present in params; wildcards are looked up by their
+// name, or by "*" when unnamed. Values are path-escaped, except that the
+// slashes of a wildcard value are kept.
+func (r *SingleThreadedRouter) URL(name string, params map[string]string) (string, error) {
+	route := r.names[name]
+	if route == nil {
+		return "", fmt.Errorf("route %q not found", name)
+	}
+
+	tokens, err := tokenize(route.Path)
+	if err != nil {
+		return "", fmt.Errorf("route %q: %v", name, err)
+	}
+
+	b := make([]byte, 0, len(route.Path)+16)
+	for _, t := range tokens {
+		switch t.kind {
+		case staticKind:
+			b = append(b, t.text...)
+		case paramKind:
+			v, ok := params[t.text]
+			if !ok || v == "" {
+				if t.optional {
+					b = bytes.TrimSuffix(b, []byte("/"))
+					continue
+				}
+				return "", fmt.Errorf("route %q: missing parameter %q", name, t.text)
+			}
+			constraints, err := parseConstraints(t.spec)
+			if err != nil {
+				return "", fmt.Errorf("route %q: %v", name, err)
+			}
+			for _, c := range constraints {
+				if !c(v) {
+					return "", fmt.Errorf("route %q: parameter %q value %q violates <%s>", name, t.text, v, t.spec)
+				}
+			}
+			b = append(b, url.PathEscape(v)...)
+		case wildcardKind:
+			key := t.text
+			if key == "" {
+				key = wildcardKey
+			}
+			v, ok := params[key]
+			if !ok {
+				return "", fmt.Errorf("route %q: missing parameter %q", name, key)
+			}
+			segments := strings.Split(v, "/")
+			for i, s := range segments {
+				segments[i] = url.PathEscape(s)
+			}
+			b = append(b, strings.Join(segments, "/")...)
+		}
+	}
+	if len(b) == 0 {
+		return "/", nil
+	}
+	return string(b), nil
+}
+
+// Named gives the route a unique name for reverse URL generation.
+// It panics if another route already uses the name.
+func (r *Route) Named(name string) *Route {
+	if r.router != nil {
+		r.router.nameRoute(r, name)
+	}
+	r.Name = name
+	return r
+}
@@ -0,0 +1,264 @@
+package TurboGo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"runtime"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// ===== LIFECYCLE =====
+
+// Listen serves HTTP on every addr until ctx is cancelled or the process
+// receives SIGINT or SIGTERM, then calls Shutdown with the ShutdownTimeout
+// deadline. It returns nil after a clean shutdown. With Config.Prefork the
+// addresses are served by child processes, see Prefork.
+//
+//	app.Listen(ctx, ":8080", "127.0.0.1:9090")
+func (a *App) Listen(ctx context.Context, addrs ...string) error {
+	if len(addrs) == 0 {
+		return errors.New("listen: no address")
+	}
+	if a.config.Prefork {
+		return a.listenPrefork(ctx, addrs)
+	}
+	lns := make([]net.Listener, 0, len(addrs))
+	urls := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		ln, err := net.Listen("tcp4", addr)
+		if err != nil {
+			closeAll(lns)
+			return err
+		}
+		lns = append(lns, ln)
+		urls = append(urls, listenURL("http", addr))
+	}
+
+	a.printStartup(urls...)
+	return a.serve(ctx, a.bind(a.newServer(), lns)...)
+}
+
+// Serve serves HTTP on listeners created elsewhere, such as by systemd socket
+// activation, with the same lifecycle as Listen. The listeners are closed on
+// shutdown.
+func (a *App) Serve(ctx context.Context, lns ...net.Listener) error {
+	if len(lns) == 0 {
+		return errors.New("serve: no listener")
+	}
+	if a.config.Prefork {
+		return errors.New(preforkNotSupported)
+	}
+	urls := make([]string, 0, len(lns))
+	for _, ln := range lns {
+		if ln.Addr().Network() == "unix" {
+			urls = append(urls, "unix:"+ln.Addr().String())
+		} else {
+			urls = append(urls, "http://"+ln.Addr().String())
+		}
+	}
+
+	a.printStartup(urls...)
+	return a.serve(ctx, a.bind(a.newServer(), lns)...)
+}
+
+// ListenUnix serves HTTP on a Unix domain socket at path with the given file
+// mode, with the same lifecycle as Listen. A stale socket left at path is
+// replaced, and the socket file is removed on shutdown.
+func (a *App) ListenUnix(ctx context.Context, path string, mode os.FileMode) error {
+	if a.config.Prefork {
+		return errors.New(preforkNotSupported)
+	}
+	if info, err := os.Stat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return fmt.Errorf("listen unix: %s exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		ln.Close()
+		return err
+	}
+
+	a.printStartup("unix:" + path)
+	return a.serve(ctx, binding{a.newServer(), ln})
+}
+
+func (a *App) bind(srv *fasthttp.Server, lns []net.Listener) []binding {
+	bindings := make([]binding, 0, len(lns))
+	for _, ln := range lns {
+		bindings = append(bindings, binding{srv, ln})
+	}
+	return bindings
+}
+
+func closeAll(lns []net.Listener) {
+	for _, ln := range lns {
+		ln.Close()
+	}
+}
+
+// listenURL is the address shown in the banner, with localhost standing in
+// for an empty host.
+func listenURL(scheme, addr string) string {
+	if strings.HasPrefix(addr, ":") {
+		return scheme + "://localhost" + addr
+	}
+	return scheme + "://" + addr
+}
+
+func (a *App) printStartup(urls ...string) {
+	runtime.GOMAXPROCS(runtime.NumCPU())
+	if !a.config.DisableBanner {
+		fmt.Println(banner(strings.Join(urls, ", ")))
+	}
+	if a.showRoutes {
+		fmt.Println(RouteTable(a.Routes()))
+	}
+}
+
+// binding is a server and a listener it serves.
+type binding struct {
+	srv *fasthttp.Server
+	ln  net.Listener
+}
+
+// serve runs every binding until ctx ends, a signal arrives, a server fails,
+// or Shutdown is called elsewhere.
+func (a *App) serve(ctx context.Context, bindings ...binding) error {
+	a.lifecycleMu.Lock()
+	if a.stopping {
+		a.lifecycleMu.Unlock()
+		for _, b := range bindings {
+			b.ln.Close()
+		}
+		return errors.New("app is shut down")
+	}
+	for _, b := range bindings {
+		if !slices.Contains(a.servers, b.srv) {
+			a.servers = append(a.servers, b.srv)
+		}
+	}
+	a.lifecycleMu.Unlock()
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errc := make(chan error, len(bindings))
+	for _, b := range bindings {
+		go func(b binding) { errc <- b.srv.Serve(b.ln) }(b)
+	}
+
+	var serveErr error
+	select {
+	case serveErr = <-errc:
+		if serveErr == nil {
+			// Shutdown was called directly; wait until it has finished.
+			<-a.stopped
+			return nil
+		}
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), a.config.ShutdownTimeout)
+	defer cancel()
+	return errors.Join(serveErr, a.Shutdown(shutdownCtx))
+}
+
+func (a *App) newServer() *fasthttp.Server {
+	cfg := a.config
+	return &fasthttp.Server{
+		Handler:            a.Handler(),
+		Name:               cfg.ServerName,
+		ReadTimeout:        cfg.ReadTimeout,
+		WriteTimeout:       cfg.WriteTimeout,
+		IdleTimeout:        cfg.IdleTimeout,
+		MaxRequestBodySize: cfg.MaxRequestBodySize,
+		Concurrency:        cfg.Concurrency,
+		ReadBufferSize:     cfg.ReadBufferSize,
+		WriteBufferSize:    cfg.WriteBufferSize,
+		MaxConnsPerIP:      cfg.MaxConnsPerIP,
+		MaxRequestsPerConn: cfg.MaxRequestsPerConn,
+		DisableKeepalive:   cfg.DisableKeepalive,
+		ReduceMemoryUsage:  cfg.ReduceMemoryUsage,
+		CloseOnShutdown:    true,
+	}
+}
+
+// ShutdownTimeout sets how long Listen waits for in-flight requests and
+// queue workers after a signal. The default is 10 seconds.
+func (a *App) ShutdownTimeout(d time.Duration) *App {
+	a.config.ShutdownTimeout = d
+	return a
+}
+
+// OnShutdown registers fn to run during Shutdown, after the server stopped
+// serving and before the engines are closed, so it may still enqueue or
+// publish. Hooks run in registration order.
+func (a *App) OnShutdown(fn func(ctx context.Context) error) *App {
+	a.lifecycleMu.Lock()
+	a.onShutdown = append(a.onShutdown, fn)
+	a.lifecycleMu.Unlock()
+	return a
+}
+
+// Shutdown stops the app in order: the servers stop accepting connections
+// and wait for in-flight requests, the OnShutdown hooks run, queue workers
+// finish their current task, then the queue and pubsub log files and the
+// cache are closed. Every step is attempted even if one fails; ctx bounds
+// the waiting. Calling Shutdown again waits for the first call to finish.
+func (a *App) Shutdown(ctx context.Context) error {
+	a.lifecycleMu.Lock()
+	if a.stopping {
+		a.lifecycleMu.Unlock()
+		select {
+		case <-a.stopped:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	a.stopping = true
+	servers, hooks := a.servers, a.onShutdown
+	a.lifecycleMu.Unlock()
+	defer close(a.stopped)
+
+	var errs []error
+	for _, srv := range servers {
+		if err := srv.ShutdownWithContext(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("http server: %w", err))
+		}
+	}
+	for _, fn := range hooks {
+		if err := fn(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if a.queue != nil {
+		if err := a.queue.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("queue: %w", err))
+		}
+	}
+	if a.pubsub != nil {
+		a.pubsub.Close()
+	}
+	if a.cache != nil {
+		a.cache.Close()
+	}
+	return errors.Join(errs...)
+}
@@ -1,24 +1,21 @@
 package middleware
 
 import (
-	"fmt"
-	"github.com/Dziqha/TurboGo/core"
-	"github.com/valyala/fasthttp"
 	"runtime/debug"
+
+	"github.com/Dziqha/TurboGo/core"
 )
 
+// Recover turns a panic in the rest of the chain into a *core.PanicError
+// recorded with SetError, so the app's ErrorHandler writes the response.
+// Apps already recover panics around every request, including chains run
+// by App.WrapHandlers; Recover lets middleware placed before it see the
+// panic as an error through c.Err().
 func Recover() core.Handler {
 	return func(c *core.Context) {
 		defer func() {
 			if r := recover(); r != nil {
-				// Log the panic with stack trace
-				fmt.Printf("[RECOVER] Panic recovered: %v\n", r)
-				fmt.Printf("[RECOVER] Stack trace:\n%s\n", debug.Stack())
-
-				// Set error response
-				c.Ctx.SetStatusCode(fasthttp.StatusInternalServerError)
-				c.Ctx.SetContentType("application/json")
-				c.Ctx.SetBodyString(`{"error":"internal server error","message":"an unexpected error occurred"}`)
+				c.SetError(&core.PanicError{Value: r, Stack: debug.Stack()})
 			}
 		}()
 
@@ -0,0 +1,111 @@
+package TurboGo
+
+import (
+	"strings"
+
+	"github.com/Dziqha/TurboGo/internal/router"
+)
+
+// Mount merges the routes of sub into a under prefix, so modules can be
+// built and tested as standalone apps and composed later:
+//
+//	billing := TurboGo.New()
+//	billing.Use(BillingAuth)
+//	billing.Get("/invoices", ListInvoices)
+//
+//	app.Mount("/billing", billing) // GET /billing/invoices
+//
+// Mounted routes run a's middleware, then sub's, then the route's own group
+// middleware and handlers. Route names, metadata, options, sub's error
+// handlers, validation rules, encoders and NotFound handlers carry over;
+// host routes stay on their host. Rules, messages and encoders a already
+// defines take precedence.
+// Engines and cookie keys set on sub are adopted by a when a has none of
+// that kind, otherwise the mounted routes share a's.
+//
+// Mount copies sub's routes at the time of the call; later changes to sub
+// are not seen by a. Path policies such as TrailingSlash follow a.
+func (a *App) Mount(prefix string, sub *App) *App {
+	if sub == nil {
+		panic("mounted app cannot be nil")
+	}
+	if sub == a {
+		panic("app cannot be mounted on itself")
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	for _, r := range sub.routes {
+		g := &Group{app: a, prefix: prefix, middleware: sub.middleware, mwNames: sub.mwNames}
+		if r.Host != "" {
+			host := a.Host(r.Host)
+			g.router, g.host = host.router, host.host
+		}
+		g.onError = sub.groups[r].errorHandler()
+		if g.onError == nil {
+			g.onError = sub.onError
+		}
+
+		path := r.Path
+		if path == "/" && prefix != "" {
+			path = ""
+		}
+		methods := r.Methods
+		if len(methods) == 0 {
+			methods = []string{r.Method}
+		}
+
+		// Keep where the route was registered on sub, so conflicts point
+		// there rather than at this Mount call.
+		route := a.addChain(g, methods, path, r.Handlers, r.Names, r.Source)
+		route.Options = r.Options
+		for k, v := range r.Metadata {
+			route.Meta(k, v)
+		}
+		if r.Name != "" {
+			route.Named(r.Name)
+		}
+	}
+
+	for _, s := range sub.notFounds {
+		a.notFounds = append(a.notFounds, scopedHandler{
+			router:  a.mountRouter(sub, s),
+			prefix:  prefix + s.prefix,
+			handler: s.handler,
+		})
+	}
+	if sub.notFound != nil {
+		a.notFounds = append(a.notFounds, scopedHandler{
+			router:  a.singleRouter,
+			prefix:  prefix,
+			handler: sub.notFound,
+		})
+	}
+
+	if a.cache == nil {
+		a.cache = sub.cache
+	}
+	if a.pubsub == nil && sub.pubsub != nil {
+		a.pubsub = sub.pubsub
+		a.EngineCtx.Pubsub = sub.pubsub
+	}
+	if a.queue == nil && sub.queue != nil {
+		a.queue = sub.queue
+		a.EngineCtx.Queue = sub.queue
+	}
+	if a.keyring == nil {
+		a.keyring = sub.keyring
+	}
+	a.validator.Merge(sub.validator)
+	a.encoders.Merge(sub.encoders)
+	return a
+}
+
+// mountRouter returns a's router matching the router s was scoped to in sub.
+func (a *App) mountRouter(sub *App, s scopedHandler) *router.SingleThreadedRouter {
+	for _, h := range sub.hosts {
+		if h.router == s.router {
+			return a.Host(h.pattern).router
+		}
+	}
+	return a.singleRouter
+}
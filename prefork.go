@@ -0,0 +1,234 @@
+package TurboGo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/valyala/fasthttp/reuseport"
+)
+
+// ===== PREFORK =====
+//
+// With prefork enabled, Listen in the master process starts one child per
+// CPU (or Config.PreforkChildren) by re-running the program. Each child
+// listens on the same addresses with SO_REUSEPORT, so the kernel spreads
+// connections between them, and runs with GOMAXPROCS=1.
+//
+// The master serves no requests. It prints the banner, restarts children
+// that exit, and on SIGINT/SIGTERM, ctx cancellation or Shutdown sends
+// SIGTERM to every child and waits ShutdownTimeout before killing it.
+// Children stop on their own if the master dies.
+//
+// Engines are per process: each child has its own cache, and its queue and
+// pubsub log files get the child's slot number, e.g. data/queue.2.json, so
+// processes never share a file. A restarted child takes over its slot's
+// file and replays its pending tasks. Pubsub messages only reach subscribers
+// in the same child.
+
+// preforkEnv carries the slot number (1..n) of a child process.
+const preforkEnv = "TURBOGO_PREFORK_CHILD"
+
+const (
+	preforkMinUptime    = time.Second // a child exiting sooner failed to start
+	preforkMaxFailures  = 3           // failed starts in a row before Listen gives up
+	preforkParentPoll   = 500 * time.Millisecond
+	preforkNotSupported = "prefork: only Listen supports prefork"
+)
+
+// IsPreforkChild reports whether this process is a prefork child.
+func IsPreforkChild() bool {
+	_, ok := preforkSlot()
+	return ok
+}
+
+func preforkSlot() (int, bool) {
+	v, ok := os.LookupEnv(preforkEnv)
+	if !ok {
+		return 0, false
+	}
+	slot, err := strconv.Atoi(v)
+	return slot, err == nil
+}
+
+// Prefork enables prefork mode for Listen with the given number of child
+// processes; 0 means one per CPU.
+func (a *App) Prefork(children int) *App {
+	a.config.Prefork = true
+	a.config.PreforkChildren = children
+	return a
+}
+
+// childFile inserts the prefork slot into a storage file name in a child,
+// "queue.json" becoming "queue.2.json".
+func childFile(name string) string {
+	slot, ok := preforkSlot()
+	if !ok {
+		return name
+	}
+	base, ext, _ := strings.Cut(name, ".")
+	return base + "." + strconv.Itoa(slot) + "." + ext
+}
+
+func (a *App) listenPrefork(ctx context.Context, addrs []string) error {
+	if IsPreforkChild() {
+		return a.preforkChild(ctx, addrs)
+	}
+	return a.preforkMaster(ctx, addrs)
+}
+
+func (a *App) preforkChild(ctx context.Context, addrs []string) error {
+	runtime.GOMAXPROCS(1)
+
+	lns := make([]net.Listener, 0, len(addrs))
+	for _, addr := range addrs {
+		ln, err := reuseport.Listen("tcp4", addr)
+		if err != nil {
+			closeAll(lns)
+			return err
+		}
+		lns = append(lns, ln)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go watchParent(ctx, cancel)
+
+	return a.serve(ctx, a.bind(a.newServer(), lns)...)
+}
+
+// watchParent cancels the child's context once the master is gone.
+func watchParent(ctx context.Context, cancel context.CancelFunc) {
+	ppid := os.Getppid()
+	ticker := time.NewTicker(preforkParentPoll)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if os.Getppid() != ppid {
+				cancel()
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (a *App) preforkMaster(ctx context.Context, addrs []string) error {
+	// Fail on a busy or invalid address here rather than in every child.
+	// The probe is closed at once: an idle SO_REUSEPORT listener in the
+	// master would still be handed connections.
+	urls := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		ln, err := reuseport.Listen("tcp4", addr)
+		if err != nil {
+			return err
+		}
+		ln.Close()
+		urls = append(urls, listenURL("http", addr))
+	}
+
+	children := a.config.PreforkChildren
+	if children <= 0 {
+		children = runtime.NumCPU()
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-a.stopped:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	errc := make(chan error, children)
+	var wg sync.WaitGroup
+	for slot := 1; slot <= children; slot++ {
+		wg.Add(1)
+		go func(slot int) {
+			defer wg.Done()
+			if err := a.superviseChild(ctx, slot); err != nil {
+				errc <- err
+			}
+		}(slot)
+	}
+
+	a.printStartup(urls...)
+	if !a.config.DisableBanner {
+		fmt.Println(CenterText(fmt.Sprintf("🔀 Prefork: %d child processes", children)))
+	}
+
+	var childErr error
+	select {
+	case childErr = <-errc:
+		cancel()
+	case <-ctx.Done():
+	}
+	wg.Wait()
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), a.config.ShutdownTimeout)
+	defer cancelShutdown()
+	return errors.Join(childErr, a.Shutdown(shutdownCtx))
+}
+
+// superviseChild runs the child of slot until ctx ends, restarting it when
+// it exits. It fails after preforkMaxFailures starts in a row that did not
+// survive preforkMinUptime.
+func (a *App) superviseChild(ctx context.Context, slot int) error {
+	failures := 0
+	for {
+		cmd := exec.Command(os.Args[0], os.Args[1:]...)
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		cmd.Env = append(os.Environ(), preforkEnv+"="+strconv.Itoa(slot))
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("prefork child %d: %w", slot, err)
+		}
+		started := time.Now()
+		done := make(chan struct{})
+		go func() {
+			cmd.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			cmd.Process.Signal(syscall.SIGTERM)
+			select {
+			case <-done:
+			case <-time.After(a.config.ShutdownTimeout):
+				cmd.Process.Kill()
+				<-done
+			}
+			return nil
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if time.Since(started) < preforkMinUptime {
+			failures++
+		} else {
+			failures = 0
+		}
+		if failures >= preforkMaxFailures {
+			return fmt.Errorf("prefork child %d keeps exiting: %s", slot, cmd.ProcessState)
+		}
+		a.logger.Warn("prefork child %d exited (%s), restarting", slot, cmd.ProcessState)
+	}
+}
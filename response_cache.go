@@ -0,0 +1,243 @@
+package TurboGo
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/Dziqha/TurboGo/internal/router"
+	"github.com/valyala/fasthttp"
+)
+
+// ===== ROUTE RESPONSE CACHE =====
+//
+// A route opts in with Route.Cache:
+//
+//	app := TurboGo.New().WithCache()
+//	app.Get("/products", listProducts).Cache(30 * time.Second).Vary("Accept-Language")
+//
+// GET and HEAD responses are stored in the app's cache engine under the route
+// and a key of the form "GET /products?page=2", plus the request host on host
+// routes and the values of the Vary headers. Hits are answered before the route's handlers run, with an Age
+// header and "X-Cache: HIT"; misses carry "X-Cache: MISS". Requests sending
+// "Cache-Control: no-cache" skip the lookup but refresh the entry, and
+// "no-store" bypasses the cache entirely.
+
+const responseCachePrefix = "turbogo:response:"
+
+// cachedResponse is the stored form of a response.
+type cachedResponse struct {
+	Status  int         `json:"status"`
+	Headers [][2]string `json:"headers"`
+	Body    []byte      `json:"body"`
+	Stored  int64       `json:"stored"`
+}
+
+// cacheable reports whether responses of route go through the cache.
+func (a *App) cacheable(route *router.Route) bool {
+	return a.cache != nil && route != nil && !route.Options.Disable && route.Options.Ttl != nil
+}
+
+// responseCache returns the handler placed in front of a cached route's chain.
+func (a *App) responseCache(route *router.Route) core.Handler {
+	return func(c *core.Context) {
+		ctx := c.Ctx
+		if !ctx.IsGet() && !ctx.IsHead() {
+			return
+		}
+
+		control := string(ctx.Request.Header.Peek(fasthttp.HeaderCacheControl))
+		if hasDirective(control, "no-store") {
+			ctx.Response.Header.Set("X-Cache", "BYPASS")
+			return
+		}
+
+		key := routeCachePrefix(route) + responseKey(ctx, route)
+		if route.Options.Force || !hasDirective(control, "no-cache") {
+			if raw, ok := a.cache.Memory.Get(key); ok {
+				var res cachedResponse
+				if json.Unmarshal(raw, &res) == nil {
+					res.write(ctx)
+					c.Abort()
+					return
+				}
+			}
+		}
+
+		c.Next()
+
+		// An error is rendered only after the chain returns, and an aborted
+		// chain may not have written its final response, so neither is
+		// stored; nor is a response varying on headers the key leaves out.
+		resp := &ctx.Response
+		if c.Err() == nil && !c.Aborted() && storable(resp) && !variesBeyond(resp, route.Options.Vary) {
+			if raw, err := json.Marshal(newCachedResponse(resp)); err == nil {
+				a.cache.Memory.Set(key, raw, *route.Options.Ttl)
+			}
+		}
+		resp.Header.Set("X-Cache", "MISS")
+	}
+}
+
+func newCachedResponse(resp *fasthttp.Response) cachedResponse {
+	res := cachedResponse{
+		Status: resp.StatusCode(),
+		Body:   append([]byte(nil), resp.Body()...),
+		Stored: time.Now().Unix(),
+	}
+	resp.Header.VisitAll(func(k, v []byte) {
+		switch string(k) {
+		case fasthttp.HeaderContentLength, fasthttp.HeaderDate, fasthttp.HeaderServer, "X-Cache", "Age":
+			return
+		}
+		res.Headers = append(res.Headers, [2]string{string(k), string(v)})
+	})
+	return res
+}
+
+func (res cachedResponse) write(ctx *fasthttp.RequestCtx) {
+	ctx.SetStatusCode(res.Status)
+	for _, h := range res.Headers {
+		ctx.Response.Header.Set(h[0], h[1])
+	}
+	ctx.SetBody(res.Body)
+
+	age := time.Now().Unix() - res.Stored
+	if age < 0 {
+		age = 0
+	}
+	ctx.Response.Header.Set("Age", strconv.FormatInt(age, 10))
+	ctx.Response.Header.Set("X-Cache", "HIT")
+}
+
+// storable reports whether a fresh response may be cached: a success other
+// than 206, without cookies and not marked private or no-store.
+func storable(resp *fasthttp.Response) bool {
+	status := resp.StatusCode()
+	if status < 200 || status >= 300 || status == fasthttp.StatusPartialContent {
+		return false
+	}
+	control := string(resp.Header.Peek(fasthttp.HeaderCacheControl))
+	if hasDirective(control, "no-store") || hasDirective(control, "private") {
+		return false
+	}
+	cookies := false
+	resp.Header.VisitAllCookie(func(_, _ []byte) { cookies = true })
+	return !cookies
+}
+
+// variesBeyond reports whether the Vary header of resp names a request
+// header missing from listed, which the cache key would then not tell apart.
+// Negotiate, for one, adds "Vary: Accept".
+func variesBeyond(resp *fasthttp.Response, listed []string) bool {
+	beyond := false
+	resp.Header.VisitAll(func(k, v []byte) {
+		if beyond || !strings.EqualFold(string(k), fasthttp.HeaderVary) {
+			return
+		}
+		for _, name := range strings.Split(string(v), ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if !slices.ContainsFunc(listed, func(h string) bool { return strings.EqualFold(h, name) }) {
+				beyond = true
+				return
+			}
+		}
+	})
+	return beyond
+}
+
+func routeCachePrefix(route *router.Route) string {
+	return responseCachePrefix + route.Host + route.Path + "\x00"
+}
+
+// responseKey is the public part of a cache key: "GET /path?a=1&b=2" with the
+// query sorted, followed by the request's host on host-scoped routes, so
+// tenants of ":tenant.example.com" never share entries, and the values of the
+// route's Vary headers.
+func responseKey(ctx *fasthttp.RequestCtx, route *router.Route) string {
+	var query []string
+	ctx.QueryArgs().VisitAll(func(k, v []byte) {
+		query = append(query, string(k)+"="+string(v))
+	})
+	sort.Strings(query)
+
+	var b strings.Builder
+	b.WriteString("GET ")
+	b.Write(ctx.Path())
+	if len(query) > 0 {
+		b.WriteByte('?')
+		b.WriteString(strings.Join(query, "&"))
+	}
+	if route.Host != "" {
+		b.WriteString("\x00host=")
+		b.WriteString(hostname(string(ctx.Host())))
+	}
+	for _, h := range route.Options.Vary {
+		b.WriteByte(0)
+		b.WriteString(strings.ToLower(h))
+		b.WriteByte('=')
+		b.Write(ctx.Request.Header.Peek(h))
+	}
+	return b.String()
+}
+
+func hasDirective(header, directive string) bool {
+	for _, part := range strings.Split(header, ",") {
+		if strings.EqualFold(strings.TrimSpace(part), directive) {
+			return true
+		}
+	}
+	return false
+}
+
+// InvalidateCache drops cached responses whose key starts with prefix and
+// returns how many were removed. Keys look like "GET /users/42?page=2", so
+// "GET /users/" clears every cached user page and "" clears everything.
+func (a *App) InvalidateCache(prefix string) int {
+	return a.dropCached(func(_, key string) bool {
+		return strings.HasPrefix(key, prefix)
+	})
+}
+
+// InvalidateRoute drops every cached response of the named route and
+// returns how many were removed.
+func (a *App) InvalidateRoute(name string) int {
+	for _, rt := range a.routers() {
+		if route := rt.NamedRoute(name); route != nil {
+			target := route.Host + route.Path
+			return a.dropCached(func(route, _ string) bool { return route == target })
+		}
+	}
+	return 0
+}
+
+// dropCached deletes the response entries accepted by match, which receives
+// the route part (host and pattern) and the public key of each entry.
+func (a *App) dropCached(match func(route, key string) bool) int {
+	if a.cache == nil {
+		return 0
+	}
+	var keys []string
+	a.cache.Memory.Range(func(k string, _ []byte) {
+		rest, ok := strings.CutPrefix(k, responseCachePrefix)
+		if !ok {
+			return
+		}
+		route, key, _ := strings.Cut(rest, "\x00")
+		key, _, _ = strings.Cut(key, "\x00")
+		if match(route, key) {
+			keys = append(keys, k)
+		}
+	})
+	for _, k := range keys {
+		a.cache.Memory.Delete(k)
+	}
+	return len(keys)
+}
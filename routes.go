@@ -0,0 +1,119 @@
+package TurboGo
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/Dziqha/TurboGo/core"
+)
+
+// RouteInfo describes one registered method/path pair.
+type RouteInfo struct {
+	Method   string         `json:"method"`
+	Path     string         `json:"path"`
+	Host     string         `json:"host,omitempty"`
+	Name     string         `json:"name,omitempty"`
+	Handlers []string       `json:"handlers"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// Routes lists every registered route in registration order, one entry per
+// method. Handlers holds the function names of the full handler chain.
+func (a *App) Routes() []RouteInfo {
+	infos := make([]RouteInfo, 0, len(a.routes))
+	for _, r := range a.routes {
+		methods := r.Methods
+		if len(methods) == 0 {
+			methods = []string{r.Method}
+		}
+		names := append(append([]string(nil), a.mwNames...), r.Names...)
+		for _, m := range methods {
+			infos = append(infos, RouteInfo{
+				Method:   m,
+				Path:     r.Path,
+				Host:     r.Host,
+				Name:     r.Name,
+				Handlers: names,
+				Metadata: r.Metadata,
+			})
+		}
+	}
+	return infos
+}
+
+// ShowRoutes toggles printing the route table below the banner in RunServer.
+func (a *App) ShowRoutes(enabled bool) *App {
+	a.showRoutes = enabled
+	return a
+}
+
+// RoutesHandler serves Routes as JSON, sorted by path then method. Mount it
+// wherever ops tooling expects it, e.g. app.Get("/debug/routes", app.RoutesHandler()).
+func (a *App) RoutesHandler() core.Handler {
+	return func(c *core.Context) {
+		infos := a.Routes()
+		sort.SliceStable(infos, func(i, j int) bool {
+			if infos[i].Path != infos[j].Path {
+				return infos[i].Path < infos[j].Path
+			}
+			return infos[i].Method < infos[j].Method
+		})
+		c.JSON(200, infos)
+	}
+}
+
+// RouteTable renders routes as an aligned text table showing each route's
+// final handler.
+func RouteTable(routes []RouteInfo) string {
+	rows := make([][4]string, 0, len(routes)+1)
+	rows = append(rows, [4]string{"METHOD", "PATH", "NAME", "HANDLER"})
+	for _, r := range routes {
+		name, handler := r.Name, ""
+		if name == "" {
+			name = "-"
+		}
+		if n := len(r.Handlers); n > 0 {
+			handler = r.Handlers[n-1]
+		}
+		rows = append(rows, [4]string{r.Method, r.Path, name, handler})
+	}
+
+	var widths [3]int
+	for _, row := range rows {
+		for i := range widths {
+			widths[i] = max(widths[i], len(row[i]))
+		}
+	}
+
+	var b strings.Builder
+	for _, row := range rows {
+		fmt.Fprintf(&b, "%-*s  %-*s  %-*s  %s\n", widths[0], row[0], widths[1], row[1], widths[2], row[2], row[3])
+	}
+	return b.String()
+}
+
+// handlerNames returns the function names of handlers as passed to Use or a
+// route method, before core.ToHandler wraps the error-returning ones.
+func handlerNames(hs []any) []string {
+	names := make([]string, 0, len(hs))
+	for _, h := range hs {
+		names = append(names, handlerName(h))
+	}
+	return names
+}
+
+func handlerName(h any) string {
+	v := reflect.ValueOf(h)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return "<nil>"
+	}
+	fn := runtime.FuncForPC(v.Pointer())
+	if fn == nil {
+		return "<unknown>"
+	}
+	return path.Base(fn.Name())
+}
@@ -0,0 +1,56 @@
+package TurboGo
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/Dziqha/TurboGo/internal/persist"
+)
+
+// FsyncPolicy decides when the queue and pubsub log files are flushed to disk.
+type FsyncPolicy = persist.SyncPolicy
+
+const (
+	FsyncAlways   = persist.SyncAlways   // after every write, the default
+	FsyncBatch    = persist.SyncBatch    // after every FsyncBatch writes
+	FsyncInterval = persist.SyncInterval // every FsyncInterval
+)
+
+// StorageOptions configures the log file of the queue and pubsub engines.
+// Zero fields keep their defaults.
+//
+//	app := TurboGo.New().
+//		WithQueue(TurboGo.StorageOptions{DataDir: t.TempDir()}).
+//		WithPubsub(TurboGo.StorageOptions{Fsync: TurboGo.FsyncInterval, Retention: time.Hour})
+type StorageOptions struct {
+	DataDir string // directory of queue.json / pubsub.json; defaults to Config.DataDir
+
+	Fsync         FsyncPolicy
+	FsyncBatch    int           // writes per fsync with FsyncBatch, default 100
+	FsyncInterval time.Duration // period of FsyncInterval, default 1s
+
+	Retention       time.Duration // completed tasks and messages older than this are dropped, default 24h
+	CompactInterval time.Duration // how often the file is compacted, default 10m
+	MaxFileSize     int64         // compact early past this many bytes; 0 = no limit
+}
+
+// storageFile returns the log file path for name and the engine options.
+// Prefork children get a file of their own, see childFile.
+func (a *App) storageFile(name string, opts []StorageOptions) (string, persist.Options) {
+	var o StorageOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	dir := o.DataDir
+	if dir == "" {
+		dir = a.config.DataDir
+	}
+	return filepath.Join(dir, childFile(name)), persist.Options{
+		Sync:            o.Fsync,
+		BatchSize:       o.FsyncBatch,
+		SyncInterval:    o.FsyncInterval,
+		Retention:       o.Retention,
+		CompactInterval: o.CompactInterval,
+		MaxFileSize:     o.MaxFileSize,
+	}
+}
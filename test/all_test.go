@@ -699,6 +699,45 @@ func TestTaskQueue_DuplicateWorkerRegistration(t *testing.T) {
 	assert.Error(t, err2)
 }
 
+func TestTaskQueue_DrainWaitsForRunningTask(t *testing.T) {
+	q := queue.NewInMem()
+	started := make(chan struct{})
+	var finished atomic.Bool
+
+	_ = q.RegisterWorker("drain", func(data []byte) error {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		finished.Store(true)
+		return nil
+	})
+	assert.NoError(t, q.Enqueue("drain", []byte("slow")))
+	<-started
+
+	assert.NoError(t, q.Shutdown(context.Background()))
+	assert.True(t, finished.Load())
+	assert.Error(t, q.Enqueue("drain", []byte("late")))
+}
+
+func TestTaskQueue_DrainTimeout(t *testing.T) {
+	q := queue.NewInMem()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	_ = q.RegisterWorker("stuck", func(data []byte) error {
+		close(started)
+		<-release
+		return nil
+	})
+	_ = q.Enqueue("stuck", []byte("task"))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := q.Drain(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
 func TestTaskQueue_EmptyQueueName(t *testing.T) {
 	q := queue.NewInMem()
 	defer q.Close()
@@ -0,0 +1,231 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+// serve runs a single request through app.Handler without a listener.
+func serve(app *TurboGo.App, method, uri string) *fasthttp.RequestCtx {
+	core.DisableLogger = true
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetMethod(method)
+	ctx.Request.SetRequestURI(uri)
+	app.Handler()(ctx)
+	return ctx
+}
+
+func TestApp_MethodNotAllowed(t *testing.T) {
+	app := TurboGo.New()
+	app.Get("/users/:id", func(c *core.Context) { c.Text(200, "get") })
+	app.Put("/users/:id", func(c *core.Context) { c.Text(200, "put") })
+
+	ctx := serve(app, "DELETE", "/users/1")
+	assert.Equal(t, 405, ctx.Response.StatusCode())
+	assert.Equal(t, "GET, HEAD, OPTIONS, PUT", string(ctx.Response.Header.Peek("Allow")))
+
+	ctx = serve(app, "DELETE", "/missing")
+	assert.Equal(t, 404, ctx.Response.StatusCode())
+	assert.Empty(t, ctx.Response.Header.Peek("Allow"))
+}
+
+func TestApp_CustomNotFoundAndMethodNotAllowed(t *testing.T) {
+	app := TurboGo.New()
+	app.Post("/items", func(c *core.Context) { c.Text(201, "created") })
+	app.NotFound(func(c *core.Context) {
+		c.JSON(404, map[string]string{"error": "no such route"})
+	})
+	app.MethodNotAllowed(func(c *core.Context) {
+		c.JSON(405, map[string]string{"allow": string(c.Ctx.Response.Header.Peek("Allow"))})
+	})
+
+	ctx := serve(app, "GET", "/nowhere")
+	assert.Equal(t, 404, ctx.Response.StatusCode())
+	assert.JSONEq(t, `{"error":"no such route"}`, string(ctx.Response.Body()))
+
+	ctx = serve(app, "GET", "/items")
+	assert.Equal(t, 405, ctx.Response.StatusCode())
+	assert.JSONEq(t, `{"allow":"OPTIONS, POST"}`, string(ctx.Response.Body()))
+}
+
+func TestApp_AutoHeadAndOptions(t *testing.T) {
+	app := TurboGo.New()
+	app.Get("/ping", func(c *core.Context) { c.Text(200, "pong") })
+	app.Post("/ping", func(c *core.Context) { c.Text(201, "posted") })
+
+	ctx := serve(app, "HEAD", "/ping")
+	assert.Equal(t, 200, ctx.Response.StatusCode())
+	assert.True(t, ctx.Response.SkipBody)
+
+	ctx = serve(app, "OPTIONS", "/ping")
+	assert.Equal(t, 204, ctx.Response.StatusCode())
+	assert.Equal(t, "GET, HEAD, OPTIONS, POST", string(ctx.Response.Header.Peek("Allow")))
+
+	ctx = serve(app, "OPTIONS", "/missing")
+	assert.Equal(t, 404, ctx.Response.StatusCode())
+}
+
+func TestApp_AutoHeadAndOptionsOptOut(t *testing.T) {
+	app := TurboGo.New()
+	app.Get("/private", func(c *core.Context) { c.Text(200, "secret") }).NoAutoHead().NoAutoOptions()
+	app.Get("/public", func(c *core.Context) { c.Text(200, "hello") })
+	app.Options("/public", func(c *core.Context) { c.Text(200, "custom") })
+
+	ctx := serve(app, "HEAD", "/private")
+	assert.Equal(t, 405, ctx.Response.StatusCode())
+	assert.Equal(t, "GET", string(ctx.Response.Header.Peek("Allow")))
+
+	ctx = serve(app, "OPTIONS", "/private")
+	assert.Equal(t, 405, ctx.Response.StatusCode())
+
+	ctx = serve(app, "OPTIONS", "/public")
+	assert.Equal(t, "custom", string(ctx.Response.Body()))
+
+	app.AutoHead(false).AutoOptions(false)
+	ctx = serve(app, "HEAD", "/public")
+	assert.Equal(t, 405, ctx.Response.StatusCode())
+	assert.Equal(t, "GET, OPTIONS", string(ctx.Response.Header.Peek("Allow")))
+}
+
+func TestApp_NamedRoutesAndURL(t *testing.T) {
+	app := TurboGo.New()
+	app.Get("/users/:id/posts/:post", func(c *core.Context) {}).Named("user.post")
+	app.Get("/files/*", func(c *core.Context) {}).Named("files")
+	app.Get("/redirect", func(c *core.Context) {
+		url, err := c.URLFor("user.post", map[string]string{"id": "7", "post": "hello"})
+		if err != nil {
+			c.Text(500, err.Error())
+			return
+		}
+		c.Text(200, url)
+	})
+
+	url, err := app.URL("user.post", "id", "a b", "post", "x/y")
+	assert.NoError(t, err)
+	assert.Equal(t, "/users/a%20b/posts/x%2Fy", url)
+
+	url, err = app.URL("files", "*", "css/site main.css")
+	assert.NoError(t, err)
+	assert.Equal(t, "/files/css/site%20main.css", url)
+
+	_, err = app.URL("user.post", "id", "7")
+	assert.ErrorContains(t, err, `missing parameter "post"`)
+
+	_, err = app.URL("unknown")
+	assert.Error(t, err)
+
+	ctx := serve(app, "GET", "/redirect")
+	assert.Equal(t, "/users/7/posts/hello", string(ctx.Response.Body()))
+}
+
+func TestApp_DuplicateRouteNamePanics(t *testing.T) {
+	app := TurboGo.New()
+	app.Get("/a", func(c *core.Context) {}).Named("dup")
+
+	assert.PanicsWithValue(t, `route name "dup" already used by GET /a`, func() {
+		app.Get("/b", func(c *core.Context) {}).Named("dup")
+	})
+}
+
+func TestContext_TypedParams(t *testing.T) {
+	app := TurboGo.New()
+	app.Get("/orders/:id<int>/:ref", func(c *core.Context) {
+		id, err := c.ParamInt("id")
+		assert.NoError(t, err)
+		assert.Equal(t, 12, id)
+
+		ref, err := c.ParamUUID("ref")
+		assert.NoError(t, err)
+		assert.Equal(t, "0b6f0c4e-8d53-4f3c-9a57-5f7b0b1e9d2a", ref.String())
+
+		_, err = c.ParamBool("ref")
+		assert.Error(t, err)
+		_, err = c.ParamInt("missing")
+		assert.ErrorContains(t, err, `param "missing" is missing`)
+		c.Text(200, "ok")
+	})
+
+	ctx := serve(app, "GET", "/orders/12/0b6f0c4e-8d53-4f3c-9a57-5f7b0b1e9d2a")
+	assert.Equal(t, 200, ctx.Response.StatusCode())
+
+	ctx = serve(app, "GET", "/orders/abc/0b6f0c4e-8d53-4f3c-9a57-5f7b0b1e9d2a")
+	assert.Equal(t, 404, ctx.Response.StatusCode())
+}
+
+func TestApp_WildcardParam(t *testing.T) {
+	app := TurboGo.New()
+	app.Get("/repos/:owner/*path", func(c *core.Context) {
+		c.Text(200, c.Param("owner")+"|"+c.Param("path"))
+	})
+
+	ctx := serve(app, "GET", "/repos/golang/src/net/http/server.go")
+	assert.Equal(t, "golang|src/net/http/server.go", string(ctx.Response.Body()))
+}
+
+func TestApp_TrailingSlashPolicies(t *testing.T) {
+	app := TurboGo.New()
+	app.Get("/users", func(c *core.Context) { c.Text(200, "users") })
+	app.Post("/items/", func(c *core.Context) { c.Text(201, "item") })
+
+	ctx := serve(app, "GET", "/users/")
+	assert.Equal(t, 404, ctx.Response.StatusCode())
+
+	app.TrailingSlash(TurboGo.TrailingSlashRedirect)
+	ctx = serve(app, "GET", "/users/?page=2")
+	assert.Equal(t, 301, ctx.Response.StatusCode())
+	assert.Equal(t, "/users?page=2", string(ctx.Response.Header.Peek("Location")))
+
+	ctx = serve(app, "POST", "/items")
+	assert.Equal(t, 308, ctx.Response.StatusCode())
+	assert.Equal(t, "/items/", string(ctx.Response.Header.Peek("Location")))
+
+	// The target keeps the escapes of the request path.
+	app.Get("/files/:name", func(c *core.Context) { c.Text(200, c.Param("name")) })
+	ctx = serve(app, "GET", "/files/a%3Fb/?v=1")
+	assert.Equal(t, 301, ctx.Response.StatusCode())
+	assert.Equal(t, "/files/a%3Fb?v=1", string(ctx.Response.Header.Peek("Location")))
+
+	// An escaped slash is part of the name, not a trailing slash.
+	ctx = serve(app, "GET", "/files/a%2F")
+	assert.NotEqual(t, 301, ctx.Response.StatusCode())
+	assert.Empty(t, ctx.Response.Header.Peek("Location"))
+
+	app.TrailingSlash(TurboGo.TrailingSlashMatch)
+	ctx = serve(app, "GET", "/users/")
+	assert.Equal(t, 200, ctx.Response.StatusCode())
+	assert.Equal(t, "users", string(ctx.Response.Body()))
+}
+
+func TestApp_CaseInsensitive(t *testing.T) {
+	app := TurboGo.New()
+	app.Get("/Users/:name", func(c *core.Context) { c.Text(200, c.Param("name")) })
+
+	ctx := serve(app, "GET", "/users/Alice")
+	assert.Equal(t, 404, ctx.Response.StatusCode())
+
+	app.CaseInsensitive(true)
+	ctx = serve(app, "GET", "/USERS/Alice")
+	assert.Equal(t, 200, ctx.Response.StatusCode())
+	assert.Equal(t, "Alice", string(ctx.Response.Body()))
+}
+
+func TestApp_CleanPath(t *testing.T) {
+	app := TurboGo.New().CleanPath(true)
+	app.Get("/admin", func(c *core.Context) { c.Text(200, "admin") })
+	app.Put("/a/b/", func(c *core.Context) { c.Text(200, "b") })
+
+	ctx := serve(app, "GET", "/users//../admin?x=1")
+	assert.Equal(t, 301, ctx.Response.StatusCode())
+	assert.Equal(t, "/admin?x=1", string(ctx.Response.Header.Peek("Location")))
+
+	ctx = serve(app, "PUT", "/a/./b/")
+	assert.Equal(t, 308, ctx.Response.StatusCode())
+	assert.Equal(t, "/a/b/", string(ctx.Response.Header.Peek("Location")))
+
+	ctx = serve(app, "GET", "/admin")
+	assert.Equal(t, 200, ctx.Response.StatusCode())
+}
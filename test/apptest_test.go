@@ -0,0 +1,125 @@
+package test
+
+import (
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/Dziqha/TurboGo/turbotest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApp_Test(t *testing.T) {
+	core.DisableLogger = true
+	app := TurboGo.New(TurboGo.Config{MaxRequestBodySize: 16})
+	app.Use(core.Handler(func(c *core.Context) {
+		c.Ctx.Response.Header.Set("X-Middleware", "yes")
+		c.Next()
+	}))
+	app.Post("/echo", func(c *core.Context) { c.Text(200, string(c.Ctx.PostBody())) })
+
+	res, err := app.Test(httptest.NewRequest("POST", "/echo", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, res.StatusCode)
+	assert.Equal(t, "yes", res.Header.Get("X-Middleware"))
+
+	res, err = app.TestRaw("POST /echo HTTP/1.1\r\nHost: example.com\r\nContent-Length: 5\r\n\r\nhello")
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(res.Body)
+	assert.Equal(t, "hello", string(body))
+
+	// Config limits apply as they do behind Listen.
+	res, err = app.TestRaw("POST /echo HTTP/1.1\r\nHost: example.com\r\nContent-Length: 32\r\n\r\n0123456789abcdef0123456789abcdef")
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, res.StatusCode, 400)
+
+	res, err = app.TestRaw("GET /missing HTTP/1.1\r\nHost: example.com\r\n\r\n")
+	assert.NoError(t, err)
+	assert.Equal(t, 404, res.StatusCode)
+}
+
+func TestApp_TestTimeout(t *testing.T) {
+	core.DisableLogger = true
+	app := TurboGo.New()
+	app.Get("/slow", func(c *core.Context) {
+		time.Sleep(200 * time.Millisecond)
+		c.Text(200, "late")
+	})
+
+	_, err := app.Test(httptest.NewRequest("GET", "/slow", nil), 20*time.Millisecond)
+	assert.ErrorContains(t, err, "no response within 20ms")
+
+	res, err := app.Test(httptest.NewRequest("GET", "/slow", nil), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, res.StatusCode)
+}
+
+func TestTurbotest_FluentAssertions(t *testing.T) {
+	core.DisableLogger = true
+	app := TurboGo.New()
+	app.Get("/users/:id", func(c *core.Context) {
+		c.Ctx.Response.Header.Set("X-Token", string(c.Ctx.Request.Header.Peek("Authorization")))
+		c.JSON(200, map[string]any{"id": c.Param("id"), "page": string(c.Ctx.QueryArgs().Peek("page"))})
+	})
+	app.Post("/users", func(c *core.Context) {
+		c.Ctx.SetContentType("application/json")
+		c.Ctx.SetStatusCode(201)
+		c.Ctx.SetBody(c.Ctx.PostBody())
+	})
+	app.Post("/login", func(c *core.Context) {
+		c.Text(200, string(c.Ctx.PostArgs().Peek("user")))
+	})
+	app.Host("admin.example.com").Get("/", func(c *core.Context) { c.Text(200, "home") })
+
+	client := turbotest.New(t, app).Header("Authorization", "Bearer abc")
+
+	var user struct{ ID, Page string }
+	client.Get("/users/7").Query("page", "2").Expect().
+		Status(200).
+		Header("X-Token", "Bearer abc").
+		NoHeader("X-Missing").
+		JSON(`{"page":"2","id":"7"}`).
+		Decode(&user)
+	assert.Equal(t, "7", user.ID)
+
+	client.Post("/users").JSON(map[string]string{"name": "Ada"}).Expect().
+		Status(201).
+		JSON(map[string]string{"name": "Ada"})
+
+	client.Post("/login").Form(url.Values{"user": {"ada"}}).Expect().
+		Body("ada")
+
+	client.Get("/").Host("admin.example.com").Expect().
+		BodyContains("home")
+	client.Get("/").Expect().Status(404)
+}
+
+func TestTurbotest_WithEngines(t *testing.T) {
+	core.DisableLogger = true
+	app := turbotest.WithEngines(t, TurboGo.New())
+	done := make(chan string, 1)
+	app.EngineCtx.Queue.RegisterWorkerAll("emails", func(task []byte) error {
+		select {
+		case done <- string(task):
+		default:
+		}
+		return nil
+	})
+	app.Post("/signup", func(c *core.Context) {
+		c.MustQueue().EnqueueAll("emails", c.Ctx.PostBody())
+		c.Text(202, "queued")
+	})
+
+	turbotest.New(t, app).Post("/signup").Body("ada@example.com").Expect().Status(202)
+
+	select {
+	case task := <-done:
+		assert.Equal(t, "ada@example.com", task)
+	case <-time.After(2 * time.Second):
+		t.Fatal("task was not processed")
+	}
+}
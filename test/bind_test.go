@@ -0,0 +1,170 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/Dziqha/TurboGo/turbotest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type Paging struct {
+	Page  int `query:"page"`
+	Limit int `query:"limit"`
+}
+
+type listOrders struct {
+	Paging
+	Tenant string        `header:"X-Tenant"`
+	Org    uuid.UUID     `param:"org"`
+	Status []string      `query:"status"`
+	Since  time.Time     `query:"since" layout:"2006-01-02"`
+	Window time.Duration `query:"window"`
+	Paid   *bool         `query:"paid"`
+	Debug  bool          `query:"-"`
+}
+
+func TestContext_BindQueryParamsAndHeaders(t *testing.T) {
+	app := TurboGo.New()
+	var got listOrders
+	app.Get("/orgs/:org/orders", func(c *core.Context) error {
+		if err := c.Bind(&got); err != nil {
+			return err
+		}
+		c.Text(200, "ok")
+		return nil
+	})
+
+	turbotest.New(t, app).
+		Get("/orgs/0b6f0c4e-8d53-4f3c-9a57-5f7b0b1e9d2a/orders?page=2&limit=50&status=paid&status=sent&since=2024-03-01&window=90m&paid=true&debug=true").
+		Header("X-Tenant", "acme").
+		Expect().
+		Status(200)
+
+	assert.Equal(t, 2, got.Page)
+	assert.Equal(t, 50, got.Limit)
+	assert.Equal(t, "acme", got.Tenant)
+	assert.Equal(t, "0b6f0c4e-8d53-4f3c-9a57-5f7b0b1e9d2a", got.Org.String())
+	assert.Equal(t, []string{"paid", "sent"}, got.Status)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), got.Since)
+	assert.Equal(t, 90*time.Minute, got.Window)
+	if assert.NotNil(t, got.Paid) {
+		assert.True(t, *got.Paid)
+	}
+	assert.False(t, got.Debug)
+}
+
+func TestContext_BindFieldErrors(t *testing.T) {
+	app := TurboGo.New()
+	app.Get("/orgs/:org/orders", func(c *core.Context) error {
+		var in listOrders
+		err := c.Bind(&in)
+		var bindErr *core.BindError
+		assert.True(t, errors.As(err, &bindErr))
+		return err
+	})
+
+	turbotest.New(t, app).
+		Get("/orgs/nope/orders?page=two&since=yesterday").
+		Expect().
+		Status(400).
+		JSON(`{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid request","code":"invalid_request","details":[
+			{"field":"org","source":"param","value":"nope","message":"invalid UUID length: 4"},
+			{"field":"page","source":"query","value":"two","message":"must be an integer"},
+			{"field":"since","source":"query","value":"yesterday","message":"must be a time like 2006-01-02"}
+		]}`)
+}
+
+type createUser struct {
+	Name  string   `json:"name" xml:"name" form:"name"`
+	Age   int      `json:"age" xml:"age" form:"age"`
+	Roles []string `json:"roles" xml:"role" form:"role"`
+	Org   string   `param:"org"`
+}
+
+func TestContext_BindBodyByContentType(t *testing.T) {
+	app := TurboGo.New()
+	var got createUser
+	app.Post("/orgs/:org/users", func(c *core.Context) error {
+		got = createUser{}
+		if err := c.Bind(&got); err != nil {
+			return err
+		}
+		c.Text(201, got.Name)
+		return nil
+	})
+	client := turbotest.New(t, app)
+	want := createUser{Name: "Ada", Age: 36, Roles: []string{"admin", "dev"}, Org: "acme"}
+
+	client.Post("/orgs/acme/users").JSON(map[string]any{"name": "Ada", "age": 36, "roles": []string{"admin", "dev"}}).
+		Expect().Status(201)
+	assert.Equal(t, want, got)
+
+	client.Post("/orgs/acme/users").Header("Content-Type", "application/xml").
+		Body(`<user><name>Ada</name><age>36</age><role>admin</role><role>dev</role></user>`).
+		Expect().Status(201)
+	assert.Equal(t, want, got)
+
+	client.Post("/orgs/acme/users").Form(url.Values{"name": {"Ada"}, "age": {"36"}, "role": {"admin", "dev"}}).
+		Expect().Status(201)
+	assert.Equal(t, want, got)
+
+	client.Post("/orgs/acme/users").Header("Content-Type", "application/json").Body(`{"name":`).
+		Expect().Status(400).BodyContains("malformed JSON body")
+
+	client.Post("/orgs/acme/users").Header("Content-Type", "application/json").Body(`{"age":"old"}`).
+		Expect().Status(400).
+		BodyContains(`{"field":"age","source":"body","message":"must be an integer"}`)
+
+	client.Post("/orgs/acme/users").Header("Content-Type", "text/csv").Body("a,b").
+		Expect().Status(415)
+}
+
+type upload struct {
+	Title string                  `form:"title"`
+	File  *multipart.FileHeader   `form:"file"`
+	Extra []*multipart.FileHeader `form:"extra"`
+}
+
+func TestContext_BindMultipart(t *testing.T) {
+	app := TurboGo.New()
+	var got upload
+	app.Post("/upload", func(c *core.Context) error {
+		if err := c.Bind(&got); err != nil {
+			return err
+		}
+		c.Text(200, "ok")
+		return nil
+	})
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	w.WriteField("title", "report")
+	fw, _ := w.CreateFormFile("file", "report.txt")
+	fw.Write([]byte("hello"))
+	for _, name := range []string{"a.txt", "b.txt"} {
+		fw, _ = w.CreateFormFile("extra", name)
+		fw.Write([]byte(name))
+	}
+	w.Close()
+
+	turbotest.New(t, app).Post("/upload").Header("Content-Type", w.FormDataContentType()).Body(body.String()).
+		Expect().Status(200)
+
+	assert.Equal(t, "report", got.Title)
+	if assert.NotNil(t, got.File) {
+		assert.Equal(t, "report.txt", got.File.Filename)
+		f, _ := got.File.Open()
+		data, _ := io.ReadAll(f)
+		assert.Equal(t, "hello", string(data))
+	}
+	assert.Len(t, got.Extra, 2)
+}
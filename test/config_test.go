@@ -0,0 +1,119 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestConfig_DefaultsAndRouterPolicies(t *testing.T) {
+	app := TurboGo.New(TurboGo.Config{
+		ReadTimeout:     5 * time.Second,
+		TrailingSlash:   TurboGo.TrailingSlashRedirect,
+		DisableAutoHead: true,
+		DisableLogger:   true,
+	})
+	cfg := app.Config()
+	assert.Equal(t, 5*time.Second, cfg.ReadTimeout)
+	assert.Equal(t, 4*1024*1024, cfg.MaxRequestBodySize)
+	assert.Equal(t, "data", cfg.DataDir)
+
+	app.Get("/users", func(c *core.Context) { c.Text(200, "users") })
+	ctx := serve(app, "GET", "/users/")
+	assert.Equal(t, 301, ctx.Response.StatusCode())
+	ctx = serve(app, "HEAD", "/users")
+	assert.Equal(t, 405, ctx.Response.StatusCode())
+}
+
+func TestConfig_Validate(t *testing.T) {
+	err := TurboGo.Config{ReadTimeout: -time.Second, LogLevel: "loud"}.Validate()
+	assert.ErrorContains(t, err, "read_timeout must not be negative")
+	assert.ErrorContains(t, err, `log_level: unknown level "loud"`)
+
+	assert.Panics(t, func() { TurboGo.New(TurboGo.Config{Concurrency: -1}) })
+	assert.NoError(t, TurboGo.DefaultConfig().Validate())
+}
+
+func TestConfig_LoadYAMLWithEnvOverride(t *testing.T) {
+	path := writeConfig(t, "turbogo.yaml", `
+server_name: billing
+read_timeout: 5s
+max_request_body_size: 1048576
+trailing_slash: match
+case_insensitive: true
+log_level: info
+`)
+	t.Setenv("TURBOGO_READ_TIMEOUT", "2s")
+	t.Setenv("TURBOGO_DISABLE_BANNER", "true")
+
+	cfg, err := TurboGo.LoadConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "billing", cfg.ServerName)
+	assert.Equal(t, 2*time.Second, cfg.ReadTimeout)
+	assert.Equal(t, 1048576, cfg.MaxRequestBodySize)
+	assert.Equal(t, TurboGo.TrailingSlashMatch, cfg.TrailingSlash)
+	assert.True(t, cfg.CaseInsensitive)
+	assert.True(t, cfg.DisableBanner)
+	assert.Equal(t, "info", cfg.LogLevel)
+	assert.Equal(t, 256*1024, cfg.Concurrency)
+}
+
+func TestConfig_LoadTOML(t *testing.T) {
+	path := writeConfig(t, "turbogo.toml", `
+write_timeout = "10s"
+disable_keepalive = true
+data_dir = "/var/lib/app"
+`)
+	cfg, err := TurboGo.LoadConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, cfg.WriteTimeout)
+	assert.True(t, cfg.DisableKeepalive)
+	assert.Equal(t, "/var/lib/app", cfg.DataDir)
+}
+
+func TestConfig_LoadErrors(t *testing.T) {
+	_, err := TurboGo.LoadConfig(writeConfig(t, "bad.yaml", "read_timout: 5s\n"))
+	assert.ErrorContains(t, err, `unknown key "read_timout"`)
+
+	_, err = TurboGo.LoadConfig(writeConfig(t, "bad.toml", "trailing_slash = \"sideways\"\n"))
+	assert.ErrorContains(t, err, `unknown trailing slash policy "sideways"`)
+
+	_, err = TurboGo.LoadConfig(writeConfig(t, "app.json", "{}"))
+	assert.ErrorContains(t, err, "unsupported format")
+
+	t.Setenv("TURBOGO_CONCURRENCY", "lots")
+	_, err = TurboGo.LoadConfig("")
+	assert.ErrorContains(t, err, "TURBOGO_CONCURRENCY")
+}
+
+func TestConfig_LoggerIsPerApp(t *testing.T) {
+	level, disabled := core.Log.Level, core.DisableLogger
+
+	quiet := TurboGo.New(TurboGo.Config{DisableLogger: true, LogLevel: "error"})
+	loud := TurboGo.New()
+
+	assert.True(t, quiet.Logger().Disabled)
+	assert.Equal(t, core.ERROR, quiet.Logger().Level)
+	assert.Same(t, core.Log, loud.Logger())
+	assert.False(t, loud.Logger().Disabled)
+	assert.Equal(t, level, core.Log.Level)
+	assert.Equal(t, disabled, core.DisableLogger)
+
+	var got *core.Logger
+	quiet.Get("/", func(c *core.Context) { got = c.Logger() })
+	serve(quiet, "GET", "/")
+	assert.Same(t, quiet.Logger(), got)
+}
@@ -0,0 +1,133 @@
+package test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/Dziqha/TurboGo/turbotest"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	cookieKeyOld = "0123456789abcdef0123456789abcdef-old"
+	cookieKeyNew = "fedcba9876543210fedcba9876543210-new"
+)
+
+func TestContext_Cookies(t *testing.T) {
+	app := TurboGo.New()
+	app.Get("/set", func(c *core.Context) {
+		c.SetCookie(core.Cookie{Name: "theme", Value: "dark", MaxAge: 3600, Secure: true, HTTPOnly: true, SameSite: core.SameSiteStrict})
+		c.SetCookie(core.Cookie{Name: "embed", Value: "1", SameSite: core.SameSiteNone, Partitioned: true})
+		c.Text(200, "ok")
+	})
+	app.Get("/read", func(c *core.Context) { c.Text(200, c.Cookie("theme")) })
+	app.Get("/clear", func(c *core.Context) { c.ClearCookie("theme") })
+	client := turbotest.New(t, app)
+
+	res := client.Get("/set").Expect().Status(200)
+	setCookies := res.Response.Header.Values("Set-Cookie")
+	if assert.Len(t, setCookies, 2) {
+		assert.Equal(t, "theme=dark; max-age=3600; path=/; HttpOnly; secure; SameSite=Strict", setCookies[0])
+		assert.Equal(t, "embed=1; path=/; secure; SameSite=None; Partitioned", setCookies[1])
+	}
+
+	client.Get("/read").Header("Cookie", "lang=en; theme=dark").Expect().Body("dark")
+
+	res = client.Get("/clear").Expect()
+	cookies := res.Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, "theme", cookies[0].Name)
+		assert.Equal(t, -1, cookies[0].MaxAge)
+	}
+}
+
+func cookieApp(keys ...string) *TurboGo.App {
+	app := TurboGo.New().CookieKeys(keys...)
+	app.Get("/login", func(c *core.Context) error {
+		if err := c.SetSignedCookie(core.Cookie{Name: "user", Value: "ada", HTTPOnly: true}); err != nil {
+			return err
+		}
+		return c.SetEncryptedCookie(core.Cookie{Name: "session", Value: "role=admin", HTTPOnly: true})
+	})
+	app.Get("/me", func(c *core.Context) error {
+		user, err := c.SignedCookie("user")
+		if err != nil {
+			return core.NewHTTPError(401, "").Wrap(err)
+		}
+		session, err := c.EncryptedCookie("session")
+		if err != nil {
+			return core.NewHTTPError(401, "").Wrap(err)
+		}
+		c.Text(200, user+" "+session)
+		return nil
+	})
+	return app
+}
+
+func cookieHeader(res *turbotest.Response) (string, map[string]string) {
+	values := map[string]string{}
+	var pairs []string
+	for _, ck := range res.Cookies() {
+		values[ck.Name] = ck.Value
+		pairs = append(pairs, ck.Name+"="+ck.Value)
+	}
+	return strings.Join(pairs, "; "), values
+}
+
+func TestContext_SignedAndEncryptedCookies(t *testing.T) {
+	client := turbotest.New(t, cookieApp(cookieKeyOld))
+
+	header, values := cookieHeader(client.Get("/login").Expect().Status(200))
+	assert.NotContains(t, values["session"], "admin")
+	client.Get("/me").Header("Cookie", header).Expect().Status(200).Body("ada role=admin")
+
+	// Tampering with either cookie is rejected.
+	_, sig, _ := strings.Cut(values["user"], ".")
+	client.Get("/me").Header("Cookie", "user=Ym9i."+sig+"; session="+values["session"]).Expect().Status(401)
+	flipped := []byte(values["session"])
+	flipped[0] ^= 1
+	client.Get("/me").Header("Cookie", "user="+values["user"]+"; session="+string(flipped)).Expect().Status(401)
+
+	// A value cannot be moved to a cookie of another name.
+	client.Get("/me").Header("Cookie", "user="+values["user"]+"; session="+values["user"]).Expect().Status(401)
+
+	// After rotation old cookies still read; dropping the old key rejects them.
+	rotated := turbotest.New(t, cookieApp(cookieKeyNew, cookieKeyOld))
+	rotated.Get("/me").Header("Cookie", header).Expect().Status(200).Body("ada role=admin")
+	newHeader, _ := cookieHeader(rotated.Get("/login").Expect())
+
+	dropped := turbotest.New(t, cookieApp(cookieKeyNew))
+	dropped.Get("/me").Header("Cookie", header).Expect().Status(401)
+	dropped.Get("/me").Header("Cookie", newHeader).Expect().Status(200)
+}
+
+func TestContext_CookieErrors(t *testing.T) {
+	app := TurboGo.New()
+	var errs []error
+	app.Get("/", func(c *core.Context) {
+		_, err := c.SignedCookie("user")
+		errs = append(errs, err, c.SetEncryptedCookie(core.Cookie{Name: "x"}))
+	})
+	turbotest.New(t, app).Get("/").Expect()
+	assert.True(t, errors.Is(errs[0], core.ErrNoKeyring))
+	assert.True(t, errors.Is(errs[1], core.ErrNoKeyring))
+
+	app = cookieApp(cookieKeyOld)
+	app.Get("/missing", func(c *core.Context) error {
+		_, err := c.SignedCookie("user")
+		assert.ErrorIs(t, err, core.ErrNoCookie)
+		_, err = c.EncryptedCookie("session")
+		assert.ErrorIs(t, err, core.ErrNoCookie)
+		return nil
+	})
+	turbotest.New(t, app).Get("/missing").Expect().Status(http.StatusOK)
+
+	assert.PanicsWithValue(t, "cookie: secret 0 is 5 bytes, need at least 32", func() {
+		TurboGo.New().CookieKeys("short")
+	})
+	assert.Panics(t, func() { TurboGo.New().CookieKeys("") })
+}
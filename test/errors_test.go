@@ -0,0 +1,162 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/Dziqha/TurboGo/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+func TestApp_ErrorReturningHandlers(t *testing.T) {
+	app := TurboGo.New()
+	app.Get("/users/:id", func(c *core.Context) error {
+		return core.NewHTTPError(404, "user not found").
+			WithCode("user_not_found").
+			WithDetails(map[string]string{"id": c.Param("id")})
+	})
+	app.Get("/db", func(c *core.Context) error { return errors.New("connection refused") })
+	app.Get("/ok", func(c *core.Context) error {
+		c.Text(200, "fine")
+		return nil
+	})
+
+	ctx := serve(app, "GET", "/users/7")
+	assert.Equal(t, 404, ctx.Response.StatusCode())
+	assert.Equal(t, "application/problem+json", string(ctx.Response.Header.ContentType()))
+	assert.JSONEq(t, `{"type":"about:blank","title":"Not Found","status":404,
+		"detail":"user not found","code":"user_not_found","details":{"id":"7"}}`, string(ctx.Response.Body()))
+
+	// Causes of plain errors are not leaked.
+	ctx = serve(app, "GET", "/db")
+	assert.Equal(t, 500, ctx.Response.StatusCode())
+	assert.NotContains(t, string(ctx.Response.Body()), "connection refused")
+
+	ctx = serve(app, "GET", "/ok")
+	assert.Equal(t, "fine", string(ctx.Response.Body()))
+}
+
+func TestApp_ErrorHandler(t *testing.T) {
+	app := TurboGo.New()
+	var got []error
+	app.ErrorHandler(func(c *core.Context, err error) {
+		got = append(got, err)
+		status := 500
+		var httpErr *core.HTTPError
+		if errors.As(err, &httpErr) {
+			status = httpErr.Status
+		}
+		c.Text(status, "handled: "+err.Error())
+	})
+	app.Use(func(c *core.Context) error {
+		if c.Header("X-Deny") != "" {
+			return core.NewHTTPError(403, "")
+		}
+		c.Next()
+		return nil
+	})
+	app.Get("/panic", func(c *core.Context) { panic("kaput") })
+	app.Get("/fail", func(c *core.Context) error {
+		return core.NewHTTPError(409, "conflict").Wrap(errors.New("duplicate key"))
+	})
+	app.Get("/", func(c *core.Context) { c.Text(200, "home") })
+
+	ctx := serve(app, "GET", "/panic")
+	assert.Equal(t, 500, ctx.Response.StatusCode())
+	assert.Equal(t, "handled: panic: kaput", string(ctx.Response.Body()))
+	var panicErr *core.PanicError
+	if assert.ErrorAs(t, got[0], &panicErr) {
+		assert.Equal(t, "kaput", panicErr.Value)
+		assert.NotEmpty(t, panicErr.Stack)
+	}
+
+	ctx = serve(app, "GET", "/fail")
+	assert.Equal(t, 409, ctx.Response.StatusCode())
+	assert.Equal(t, "handled: 409 conflict: duplicate key", string(ctx.Response.Body()))
+
+	// Errors from middleware go through the same handler.
+	ctx = serveWithHeader(app, "/", "X-Deny", "1")
+	assert.Equal(t, 403, ctx.Response.StatusCode())
+	assert.Equal(t, "handled: 403 Forbidden", string(ctx.Response.Body()))
+}
+
+func TestApp_ErrorHandlerPrecedence(t *testing.T) {
+	app := TurboGo.New()
+	app.ErrorHandler(func(c *core.Context, err error) { c.Text(500, "app") })
+	api := app.Group("/api").ErrorHandler(func(c *core.Context, err error) { c.Text(500, "api") })
+	api.Get("/fail", func(c *core.Context) error { return errors.New("x") })
+	app.Get("/fail", func(c *core.Context) error { return errors.New("x") })
+
+	assert.Equal(t, "api", string(serve(app, "GET", "/api/fail").Response.Body()))
+	assert.Equal(t, "app", string(serve(app, "GET", "/fail").Response.Body()))
+
+	// A sub app's handler still applies once mounted.
+	sub := TurboGo.New().ErrorHandler(func(c *core.Context, err error) { c.Text(500, "sub") })
+	sub.Get("/fail", func(c *core.Context) error { return errors.New("x") })
+	app.Mount("/sub", sub)
+	assert.Equal(t, "sub", string(serve(app, "GET", "/sub/fail").Response.Body()))
+}
+
+func TestApp_MiddlewareSeesHandlerError(t *testing.T) {
+	app := TurboGo.New()
+	app.Use(func(c *core.Context) {
+		c.Next()
+		if errors.Is(c.Err(), errSoft) {
+			c.SetError(nil)
+			c.Text(200, "recovered")
+		}
+	})
+	app.Get("/soft", func(c *core.Context) error { return errSoft })
+
+	ctx := serve(app, "GET", "/soft")
+	assert.Equal(t, 200, ctx.Response.StatusCode())
+	assert.Equal(t, "recovered", string(ctx.Response.Body()))
+}
+
+var errSoft = errors.New("soft")
+
+func TestApp_WrapHandlersRendersErrors(t *testing.T) {
+	app := TurboGo.New()
+	call := func(hs ...core.Handler) *fasthttp.RequestCtx {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.SetRequestURI("/")
+		app.WrapHandlers(hs)(ctx)
+		return ctx
+	}
+
+	ctx := call(func(c *core.Context) { panic("kaput") })
+	assert.Equal(t, 500, ctx.Response.StatusCode())
+	assert.Equal(t, "application/problem+json", string(ctx.Response.Header.ContentType()))
+
+	ctx = call(middleware.Recover(), func(c *core.Context) { panic("kaput") })
+	assert.Equal(t, 500, ctx.Response.StatusCode())
+	assert.Equal(t, "application/problem+json", string(ctx.Response.Header.ContentType()))
+
+	ctx = call(func(c *core.Context) { c.JSON(200, make(chan int)) })
+	assert.Equal(t, 500, ctx.Response.StatusCode())
+
+	app.ErrorHandler(func(c *core.Context, err error) { c.Text(502, "handled") })
+	ctx = call(func(c *core.Context) { c.SetError(errSoft) })
+	assert.Equal(t, 502, ctx.Response.StatusCode())
+	assert.Equal(t, "handled", string(ctx.Response.Body()))
+
+	ctx = call(func(c *core.Context) { c.Text(200, "fine") })
+	assert.Equal(t, "fine", string(ctx.Response.Body()))
+}
+
+func TestApp_RoutesKeepHandlerNames(t *testing.T) {
+	app := TurboGo.New()
+	app.Get("/named", namedErrorHandler)
+
+	routes := app.Routes()
+	assert.Equal(t, []string{"test.namedErrorHandler"}, routes[0].Handlers)
+
+	assert.PanicsWithValue(t, "handler must be func(*core.Context) or func(*core.Context) error, got string", func() {
+		app.Get("/bad", "nope")
+	})
+}
+
+func namedErrorHandler(c *core.Context) error { return nil }
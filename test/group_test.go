@@ -0,0 +1,139 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/stretchr/testify/assert"
+)
+
+// trace appends name to the X-Trace response header and continues the chain.
+func trace(name string) core.Handler {
+	return func(c *core.Context) {
+		value := name
+		if prev := string(c.Ctx.Response.Header.Peek("X-Trace")); prev != "" {
+			value = prev + "," + name
+		}
+		c.Ctx.Response.Header.Set("X-Trace", value)
+		c.Next()
+	}
+}
+
+func TestGroup_NestedPrefixAndMiddleware(t *testing.T) {
+	app := TurboGo.New()
+	app.Use(core.Handler(func(c *core.Context) {
+		c.Ctx.Response.Header.Set("X-Trace", "app")
+		c.Next()
+	}))
+
+	api := app.Group("/api", trace("api"))
+	admin := api.Group("/v1").Group("/admin", trace("admin"))
+	admin.Get("/stats", func(c *core.Context) { c.Text(200, "stats") })
+
+	ctx := serve(app, "GET", "/api/v1/admin/stats")
+	assert.Equal(t, 200, ctx.Response.StatusCode())
+	assert.Equal(t, "stats", string(ctx.Response.Body()))
+	assert.Equal(t, "app,api,admin", string(ctx.Response.Header.Peek("X-Trace")))
+}
+
+func TestGroup_LateUse(t *testing.T) {
+	app := TurboGo.New()
+	api := app.Group("/api")
+	api.Get("/before", func(c *core.Context) { c.Text(200, "before") })
+	api.Use(trace("late"))
+	api.Group("/v2").Get("/after", func(c *core.Context) { c.Text(200, "after") })
+
+	ctx := serve(app, "GET", "/api/before")
+	assert.Empty(t, ctx.Response.Header.Peek("X-Trace"))
+
+	ctx = serve(app, "GET", "/api/v2/after")
+	assert.Equal(t, "late", string(ctx.Response.Header.Peek("X-Trace")))
+}
+
+func TestGroup_AbortStopsChain(t *testing.T) {
+	app := TurboGo.New()
+	private := app.Group("/private", func(c *core.Context) {
+		c.Text(401, "unauthorized")
+		c.Abort()
+	})
+	private.Get("/data", func(c *core.Context) { c.Text(200, "data") })
+
+	ctx := serve(app, "GET", "/private/data")
+	assert.Equal(t, 401, ctx.Response.StatusCode())
+	assert.Equal(t, "unauthorized", string(ctx.Response.Body()))
+}
+
+func TestGroup_Metadata(t *testing.T) {
+	app := TurboGo.New()
+	api := app.Group("/api").Meta("auth", "token").Meta("version", 1)
+	v2 := api.Group("/v2").Meta("version", 2)
+	v2.Get("/users", listUsers)
+	v2.Get("/public", listUsers).Meta("auth", "none")
+
+	routes := app.Routes()
+	assert.Len(t, routes, 2)
+	assert.Equal(t, map[string]any{"auth": "token", "version": 2}, routes[0].Metadata)
+	assert.Equal(t, map[string]any{"auth": "none", "version": 2}, routes[1].Metadata)
+}
+
+func TestGroup_ScopedNotFound(t *testing.T) {
+	app := TurboGo.New()
+	api := app.Group("/api")
+	api.Get("/users", listUsers)
+	api.NotFound(func(c *core.Context) { c.JSON(404, map[string]string{"error": "unknown endpoint"}) })
+	admin := api.Group("/admin")
+	admin.NotFound(func(c *core.Context) { c.Text(404, "admin: not found") })
+
+	ctx := serve(app, "GET", "/api/nope")
+	assert.Equal(t, 404, ctx.Response.StatusCode())
+	assert.JSONEq(t, `{"error":"unknown endpoint"}`, string(ctx.Response.Body()))
+
+	ctx = serve(app, "GET", "/api/admin/nope")
+	assert.Equal(t, "admin: not found", string(ctx.Response.Body()))
+
+	ctx = serve(app, "POST", "/api/users")
+	assert.Equal(t, 405, ctx.Response.StatusCode())
+
+	ctx = serve(app, "GET", "/api")
+	assert.JSONEq(t, `{"error":"unknown endpoint"}`, string(ctx.Response.Body()))
+
+	ctx = serve(app, "GET", "/elsewhere")
+	assert.Equal(t, 404, ctx.Response.StatusCode())
+	assert.NotContains(t, string(ctx.Response.Body()), "unknown endpoint")
+
+	// A path that only shares the prefix's letters is not below it.
+	ctx = serve(app, "GET", "/apiary")
+	assert.Equal(t, 404, ctx.Response.StatusCode())
+	assert.NotContains(t, string(ctx.Response.Body()), "unknown endpoint")
+	ctx = serve(app, "GET", "/api/administrator")
+	assert.JSONEq(t, `{"error":"unknown endpoint"}`, string(ctx.Response.Body()))
+}
+
+func TestGroup_ErrorHandler(t *testing.T) {
+	app := TurboGo.New()
+	api := app.Group("/api").ErrorHandler(func(c *core.Context, err error) {
+		c.JSON(500, map[string]string{"error": err.Error()})
+	})
+	api.Group("/v1").Get("/boom", func(c *core.Context) { panic(errors.New("kaput")) })
+	app.Get("/boom", func(c *core.Context) { panic("kaput") })
+
+	ctx := serve(app, "GET", "/api/v1/boom")
+	assert.Equal(t, 500, ctx.Response.StatusCode())
+	assert.JSONEq(t, `{"error":"panic: kaput"}`, string(ctx.Response.Body()))
+
+	ctx = serve(app, "GET", "/boom")
+	assert.Equal(t, 500, ctx.Response.StatusCode())
+	assert.JSONEq(t, `{"type":"about:blank","title":"Internal Server Error","status":500}`, string(ctx.Response.Body()))
+}
+
+func TestGroup_HeadAndConnect(t *testing.T) {
+	app := TurboGo.New()
+	g := app.Group("/g")
+	g.Head("/h", func(c *core.Context) { c.Ctx.SetStatusCode(204) })
+	g.Connect("/c", func(c *core.Context) { c.Text(200, "tunnel") })
+
+	assert.Equal(t, 204, serve(app, "HEAD", "/g/h").Response.StatusCode())
+	assert.Equal(t, "tunnel", string(serve(app, "CONNECT", "/g/c").Response.Body()))
+}
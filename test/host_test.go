@@ -0,0 +1,69 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+func serveHost(app *TurboGo.App, method, host, uri string) *fasthttp.RequestCtx {
+	core.DisableLogger = true
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetMethod(method)
+	ctx.Request.SetRequestURI(uri)
+	ctx.Request.Header.SetHost(host)
+	app.Handler()(ctx)
+	return ctx
+}
+
+func TestApp_HostRouting(t *testing.T) {
+	app := TurboGo.New()
+	app.Get("/", func(c *core.Context) { c.Text(200, "default") })
+	app.Get("/healthz", func(c *core.Context) { c.Text(200, "ok") })
+
+	api := app.Host("api.example.com")
+	api.Get("/", func(c *core.Context) { c.Text(200, "api") })
+
+	tenant := app.Host(":tenant.example.com")
+	tenant.Get("/users/:id", func(c *core.Context) {
+		c.Text(200, c.Param("tenant")+"/"+c.Param("id"))
+	}).Named("tenant.user")
+
+	ctx := serveHost(app, "GET", "api.example.com:8080", "/")
+	assert.Equal(t, "api", string(ctx.Response.Body()))
+
+	ctx = serveHost(app, "GET", "Acme.Example.com", "/users/7")
+	assert.Equal(t, "acme/7", string(ctx.Response.Body()))
+
+	ctx = serveHost(app, "GET", "other.org", "/")
+	assert.Equal(t, "default", string(ctx.Response.Body()))
+
+	// Paths a host does not serve fall back to the default table.
+	ctx = serveHost(app, "GET", "acme.example.com", "/healthz")
+	assert.Equal(t, "ok", string(ctx.Response.Body()))
+
+	ctx = serveHost(app, "GET", "a.b.example.com", "/users/7")
+	assert.Equal(t, 404, ctx.Response.StatusCode())
+
+	ctx = serveHost(app, "POST", "acme.example.com", "/users/7")
+	assert.Equal(t, 405, ctx.Response.StatusCode())
+
+	url, err := app.URL("tenant.user", "id", "7")
+	assert.NoError(t, err)
+	assert.Equal(t, "/users/7", url)
+
+	routes := app.Routes()
+	assert.Equal(t, ":tenant.example.com", routes[len(routes)-1].Host)
+}
+
+func TestApp_HostScopeIsShared(t *testing.T) {
+	app := TurboGo.New()
+	app.Host("API.example.com").Get("/a", func(c *core.Context) { c.Text(200, "a") })
+	app.Host("api.example.com").Get("/b", func(c *core.Context) { c.Text(200, "b") })
+
+	assert.Equal(t, "a", string(serveHost(app, "GET", "api.example.com", "/a").Response.Body()))
+	assert.Equal(t, "b", string(serveHost(app, "GET", "api.example.com", "/b").Response.Body()))
+}
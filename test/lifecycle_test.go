@@ -0,0 +1,188 @@
+package test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+// freeAddr returns a local address that was free a moment ago.
+func freeAddr(t *testing.T) string {
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	return ln.Addr().String()
+}
+
+func TestApp_ListenDrainsInFlightRequests(t *testing.T) {
+	core.DisableLogger = true
+	app := TurboGo.New()
+	started := make(chan struct{})
+	app.Get("/slow", func(c *core.Context) {
+		close(started)
+		time.Sleep(150 * time.Millisecond)
+		c.Text(200, "done")
+	})
+	var hooks atomic.Int32
+	app.OnShutdown(func(ctx context.Context) error {
+		hooks.Add(1)
+		return nil
+	})
+
+	addr := freeAddr(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	listenErr := make(chan error, 1)
+	go func() { listenErr <- app.Listen(ctx, addr) }()
+
+	status := make(chan int, 1)
+	go func() {
+		var code int
+		for i := 0; i < 50; i++ {
+			code, _, _ = fasthttp.Get(nil, "http://"+addr+"/slow")
+			if code != 0 {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		status <- code
+	}()
+
+	<-started
+	cancel()
+
+	assert.Equal(t, 200, <-status)
+	assert.NoError(t, <-listenErr)
+	assert.Equal(t, int32(1), hooks.Load())
+
+	// A second Shutdown is a no-op.
+	assert.NoError(t, app.Shutdown(context.Background()))
+	assert.Equal(t, int32(1), hooks.Load())
+}
+
+func TestApp_ShutdownStopsListen(t *testing.T) {
+	core.DisableLogger = true
+	app := TurboGo.New().ShutdownTimeout(time.Second)
+	app.Get("/", func(c *core.Context) { c.Text(200, "ok") })
+
+	addr := freeAddr(t)
+	listenErr := make(chan error, 1)
+	go func() { listenErr <- app.Listen(context.Background(), addr) }()
+
+	var code int
+	for i := 0; i < 50 && code == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+		code, _, _ = fasthttp.Get(nil, "http://"+addr+"/")
+	}
+	assert.Equal(t, 200, code)
+
+	assert.NoError(t, app.Shutdown(context.Background()))
+	select {
+	case err := <-listenErr:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Listen did not return after Shutdown")
+	}
+}
+
+// getWhenUp retries a GET until the server answers.
+func getWhenUp(client *fasthttp.Client, url string) (int, string) {
+	for i := 0; i < 50; i++ {
+		code, body, err := client.Get(nil, url)
+		if err == nil {
+			return code, string(body)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return 0, ""
+}
+
+func TestApp_ListenMultipleAddresses(t *testing.T) {
+	core.DisableLogger = true
+	app := TurboGo.New(TurboGo.Config{DisableBanner: true})
+	app.Get("/", func(c *core.Context) { c.Text(200, "ok") })
+
+	first, second := freeAddr(t), freeAddr(t)
+	listenErr := make(chan error, 1)
+	go func() { listenErr <- app.Listen(context.Background(), first, second) }()
+
+	client := &fasthttp.Client{}
+	for _, addr := range []string{first, second} {
+		code, body := getWhenUp(client, "http://"+addr+"/")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, "ok", body)
+	}
+
+	assert.NoError(t, app.Shutdown(context.Background()))
+	assert.NoError(t, <-listenErr)
+	_, _, err := fasthttp.Get(nil, "http://"+second+"/")
+	assert.Error(t, err)
+
+	assert.Error(t, TurboGo.New().Listen(context.Background()))
+}
+
+func TestApp_Serve(t *testing.T) {
+	core.DisableLogger = true
+	app := TurboGo.New(TurboGo.Config{DisableBanner: true})
+	app.Get("/", func(c *core.Context) { c.Text(200, "served") })
+
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- app.Serve(ctx, ln) }()
+
+	code, body := getWhenUp(&fasthttp.Client{}, "http://"+ln.Addr().String()+"/")
+	assert.Equal(t, 200, code)
+	assert.Equal(t, "served", body)
+
+	cancel()
+	assert.NoError(t, <-serveErr)
+}
+
+func TestApp_ListenUnix(t *testing.T) {
+	core.DisableLogger = true
+	app := TurboGo.New(TurboGo.Config{DisableBanner: true})
+	app.Get("/", func(c *core.Context) { c.Text(200, "unix") })
+
+	path := filepath.Join(t.TempDir(), "app.sock")
+	listenErr := make(chan error, 1)
+	go func() { listenErr <- app.ListenUnix(context.Background(), path, 0o660) }()
+
+	client := &fasthttp.Client{
+		Dial: func(string) (net.Conn, error) { return net.Dial("unix", path) },
+	}
+	code, body := getWhenUp(client, "http://unix/")
+	assert.Equal(t, 200, code)
+	assert.Equal(t, "unix", body)
+
+	info, err := os.Stat(path)
+	if assert.NoError(t, err) {
+		assert.Equal(t, os.FileMode(0o660), info.Mode().Perm())
+	}
+
+	assert.NoError(t, app.Shutdown(context.Background()))
+	assert.NoError(t, <-listenErr)
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestApp_ListenUnixRefusesRegularFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.sock")
+	assert.NoError(t, os.WriteFile(path, []byte("data"), 0o644))
+
+	err := TurboGo.New().ListenUnix(context.Background(), path, 0o660)
+	assert.ErrorContains(t, err, "is not a socket")
+}
@@ -0,0 +1,122 @@
+package test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBillingApp() *TurboGo.App {
+	billing := TurboGo.New()
+	billing.Use(trace("billing"))
+	billing.Get("/", func(c *core.Context) { c.Text(200, "billing home") })
+	billing.Get("/invoices/:id<int>", func(c *core.Context) {
+		c.Text(200, "invoice "+c.Param("id"))
+	}).Named("invoice").Meta("owner", "billing")
+
+	admin := billing.Group("/admin", trace("admin"))
+	admin.Get("/boom", func(c *core.Context) { panic("kaput") })
+	admin.ErrorHandler(func(c *core.Context, err error) { c.Text(500, "billing: "+err.Error()) })
+	billing.NotFound(func(c *core.Context) { c.Text(404, "no such billing page") })
+	return billing
+}
+
+func TestMount_MergesRoutesAndMiddleware(t *testing.T) {
+	billing := newBillingApp()
+	ctx := serve(billing, "GET", "/invoices/7")
+	assert.Equal(t, "invoice 7", string(ctx.Response.Body()))
+
+	app := TurboGo.New()
+	app.Use(core.Handler(func(c *core.Context) {
+		c.Ctx.Response.Header.Set("X-Trace", "app")
+		c.Next()
+	}))
+	app.Get("/", func(c *core.Context) { c.Text(200, "home") })
+	app.Mount("/billing/", billing)
+
+	ctx = serve(app, "GET", "/billing/invoices/7")
+	assert.Equal(t, 200, ctx.Response.StatusCode())
+	assert.Equal(t, "invoice 7", string(ctx.Response.Body()))
+	assert.Equal(t, "app,billing", string(ctx.Response.Header.Peek("X-Trace")))
+
+	ctx = serve(app, "GET", "/billing")
+	assert.Equal(t, "billing home", string(ctx.Response.Body()))
+
+	ctx = serve(app, "GET", "/")
+	assert.Equal(t, "home", string(ctx.Response.Body()))
+	assert.Equal(t, "app", string(ctx.Response.Header.Peek("X-Trace")))
+
+	ctx = serve(app, "GET", "/billing/admin/boom")
+	assert.Equal(t, 500, ctx.Response.StatusCode())
+	assert.Equal(t, "billing: panic: kaput", string(ctx.Response.Body()))
+	assert.Equal(t, "app,billing,admin", string(ctx.Response.Header.Peek("X-Trace")))
+}
+
+func TestMount_NamesMetadataAndNotFound(t *testing.T) {
+	app := TurboGo.New().Mount("/billing", newBillingApp())
+
+	url, err := app.URL("invoice", "id", "42")
+	assert.NoError(t, err)
+	assert.Equal(t, "/billing/invoices/42", url)
+	assert.Equal(t, "billing", app.Route("/billing/invoices/:id<int>").Metadata["owner"])
+
+	ctx := serve(app, "GET", "/billing/nope")
+	assert.Equal(t, 404, ctx.Response.StatusCode())
+	assert.Equal(t, "no such billing page", string(ctx.Response.Body()))
+
+	ctx = serve(app, "GET", "/nope")
+	assert.Equal(t, 404, ctx.Response.StatusCode())
+	assert.NotEqual(t, "no such billing page", string(ctx.Response.Body()))
+
+	ctx = serve(app, "GET", "/billingx")
+	assert.Equal(t, 404, ctx.Response.StatusCode())
+	assert.NotEqual(t, "no such billing page", string(ctx.Response.Body()))
+}
+
+func TestMount_HostRoutes(t *testing.T) {
+	sub := TurboGo.New()
+	sub.Host("api.example.com").Get("/status", func(c *core.Context) { c.Text(200, "api ok") })
+
+	app := TurboGo.New().Mount("/v1", sub)
+	ctx := serveHost(app, "GET", "api.example.com", "/v1/status")
+	assert.Equal(t, "api ok", string(ctx.Response.Body()))
+
+	ctx = serveHost(app, "GET", "www.example.com", "/v1/status")
+	assert.Equal(t, 404, ctx.Response.StatusCode())
+}
+
+func TestMount_Panics(t *testing.T) {
+	app := TurboGo.New()
+	assert.Panics(t, func() { app.Mount("/x", nil) })
+	assert.Panics(t, func() { app.Mount("/x", app) })
+}
+
+func TestMount_AdoptsEnginesIntoEngineContext(t *testing.T) {
+	dir := t.TempDir()
+	sub := TurboGo.New().WithQueue(TurboGo.StorageOptions{DataDir: dir}).WithPubsub(TurboGo.StorageOptions{DataDir: dir})
+	app := TurboGo.New().Mount("/sub", sub)
+
+	assert.Same(t, sub.EngineCtx.Queue, app.EngineCtx.Queue)
+	assert.Same(t, sub.EngineCtx.Pubsub, app.EngineCtx.Pubsub)
+}
+
+func TestMount_ConflictsPointAtOriginalRegistration(t *testing.T) {
+	sub := TurboGo.New()
+	sub.Get("/users", listUsers) // registered here
+	_, _, line, _ := runtime.Caller(0)
+
+	app := TurboGo.New().StrictRouting(true)
+	app.Get("/api/users", listUsers)
+
+	defer func() {
+		err, ok := recover().(*TurboGo.RouteConflictError)
+		if assert.True(t, ok) {
+			assert.Equal(t, fmt.Sprintf("mount_test.go:%d", line-1), err.Route.Source)
+		}
+	}()
+	app.Mount("/api", sub)
+}
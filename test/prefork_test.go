@@ -0,0 +1,117 @@
+package test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+// Prefork children re-run the test binary; TestMain turns them into servers
+// for the address and data directory the parent test put in the environment.
+func TestMain(m *testing.M) {
+	if TurboGo.IsPreforkChild() {
+		preforkApp().Listen(context.Background(), os.Getenv("PREFORK_TEST_ADDR"))
+		return
+	}
+	os.Exit(m.Run())
+}
+
+func preforkApp() *TurboGo.App {
+	core.DisableLogger = true
+	app := TurboGo.New(TurboGo.Config{DisableBanner: true, ShutdownTimeout: time.Second}).
+		Prefork(2).
+		WithQueue(TurboGo.StorageOptions{DataDir: os.Getenv("PREFORK_TEST_DIR")})
+	app.Get("/pid", func(c *core.Context) {
+		c.MustQueue().EnqueueAll("jobs", []byte("job"))
+		c.Text(200, os.Getenv("TURBOGO_PREFORK_CHILD")+" "+strconv.Itoa(os.Getpid()))
+	})
+	// POST, so that the client does not retry it on the next child.
+	app.Post("/crash", func(c *core.Context) { os.Exit(1) })
+	return app
+}
+
+func TestApp_Prefork(t *testing.T) {
+	addr, dir := freeAddr(t), t.TempDir()
+	t.Setenv("PREFORK_TEST_ADDR", addr)
+	t.Setenv("PREFORK_TEST_DIR", dir)
+
+	app := preforkApp()
+	ctx, cancel := context.WithCancel(context.Background())
+	listenErr := make(chan error, 1)
+	go func() { listenErr <- app.Listen(ctx, addr) }()
+
+	code, _ := getWhenUp(&fasthttp.Client{}, "http://"+addr+"/pid")
+	assert.Equal(t, 200, code)
+
+	// Wait until both children answer, so the crash below cannot take down
+	// the only one listening while the other is still starting.
+	slots := map[string]string{}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(slots) < 2 && time.Now().Before(deadline) {
+		if code, body, err := getFresh("http://" + addr + "/pid"); err == nil && code == 200 {
+			slot, pid, _ := strings.Cut(body, " ")
+			slots[slot] = pid
+		}
+	}
+	if !assert.Len(t, slots, 2, "both prefork children should answer") {
+		cancel()
+		<-listenErr
+		return
+	}
+	for slot, pid := range slots {
+		assert.NotEqual(t, strconv.Itoa(os.Getpid()), pid)
+
+		// Each child keeps its own queue file.
+		_, err := os.Stat(filepath.Join(dir, "queue."+slot+".json"))
+		assert.NoError(t, err)
+	}
+	_, err := os.Stat(filepath.Join(dir, "queue.json"))
+	assert.True(t, os.IsNotExist(err))
+
+	// A crashed child is replaced and the service keeps answering.
+	_, _, err = fasthttp.Post(nil, "http://"+addr+"/crash", nil)
+	assert.Error(t, err)
+	for i := 0; i < 10; i++ {
+		code, _, err = getFresh("http://" + addr + "/pid")
+		if assert.NoError(t, err) {
+			assert.Equal(t, 200, code)
+		}
+	}
+
+	cancel()
+	select {
+	case err := <-listenErr:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Listen did not return after cancel")
+	}
+	_, _, err = getFresh("http://" + addr + "/pid")
+	assert.Error(t, err)
+}
+
+// getFresh sends a GET on a new connection, which the kernel hands to any of
+// the children sharing the port.
+func getFresh(url string) (int, string, error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(res)
+	req.SetRequestURI(url)
+	req.SetConnectionClose()
+	err := fasthttp.DoTimeout(req, res, time.Second)
+	return res.StatusCode(), string(res.Body()), err
+}
+
+func TestApp_PreforkOnlyWithListen(t *testing.T) {
+	app := TurboGo.New().Prefork(2)
+	err := app.ListenUnix(context.Background(), filepath.Join(t.TempDir(), "app.sock"), 0o600)
+	assert.ErrorContains(t, err, "only Listen supports prefork")
+}
@@ -0,0 +1,128 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/Dziqha/TurboGo/turbotest"
+	"github.com/stretchr/testify/assert"
+	"github.com/ugorji/go/codec"
+)
+
+type book struct {
+	ID    int    `json:"id" xml:"id" yaml:"id"`
+	Title string `json:"title" xml:"title" yaml:"title"`
+}
+
+func TestContext_Encoders(t *testing.T) {
+	app := TurboGo.New()
+	b := book{ID: 1, Title: "Dune"}
+	app.Get("/xml", func(c *core.Context) { c.XML(200, b) })
+	app.Get("/html", func(c *core.Context) { c.HTML(200, "<h1>Dune</h1>") })
+	app.Get("/yaml", func(c *core.Context) { c.YAML(200, b) })
+	app.Get("/msgpack", func(c *core.Context) { c.MsgPack(200, b) })
+	app.Get("/cbor", func(c *core.Context) { c.CBOR(200, b) })
+	app.Get("/pretty", func(c *core.Context) { c.JSONPretty(200, b) })
+	app.Get("/jsonp", func(c *core.Context) { c.JSONP(200, c.Query("callback"), b) })
+	client := turbotest.New(t, app)
+
+	client.Get("/xml").Expect().
+		Header("Content-Type", "application/xml").
+		Body(`<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<book><id>1</id><title>Dune</title></book>`)
+	client.Get("/html").Expect().
+		Header("Content-Type", "text/html; charset=utf-8").
+		Body("<h1>Dune</h1>")
+	client.Get("/yaml").Expect().
+		Header("Content-Type", "application/yaml").
+		Body("id: 1\ntitle: Dune\n")
+	client.Get("/pretty").Expect().
+		Header("Content-Type", "application/json").
+		Body("{\n  \"id\": 1,\n  \"title\": \"Dune\"\n}")
+
+	var got book
+	res := client.Get("/msgpack").Expect().Header("Content-Type", "application/msgpack")
+	assert.NoError(t, codec.NewDecoderBytes([]byte(res.Text()), &codec.MsgpackHandle{}).Decode(&got))
+	assert.Equal(t, b, got)
+
+	got = book{}
+	res = client.Get("/cbor").Expect().Header("Content-Type", "application/cbor")
+	assert.NoError(t, codec.NewDecoderBytes([]byte(res.Text()), &codec.CborHandle{}).Decode(&got))
+	assert.Equal(t, b, got)
+
+	client.Get("/jsonp").Query("callback", "app.render").Expect().
+		Status(200).
+		Header("Content-Type", "application/javascript; charset=utf-8").
+		Header("X-Content-Type-Options", "nosniff").
+		Body(`/**/app.render({"id":1,"title":"Dune"});`)
+	client.Get("/jsonp").Query("callback", "alert(1)//").Expect().
+		Status(400).
+		BodyContains(`"code":"invalid_callback"`)
+	client.Get("/jsonp").Expect().
+		Header("Content-Type", "application/json").
+		Body(`{"id":1,"title":"Dune"}`)
+}
+
+func TestContext_Negotiate(t *testing.T) {
+	app := TurboGo.New()
+	app.Get("/book", func(c *core.Context) { c.Negotiate(200, book{ID: 1, Title: "Dune"}) })
+	client := turbotest.New(t, app)
+
+	cases := []struct {
+		accept, want string
+	}{
+		{"", "application/json"},
+		{"*/*", "application/json"},
+		{"application/xml", "application/xml"},
+		{"application/json;q=0.5, application/yaml", "application/yaml"},
+		{"text/html, application/*;q=0.8", "application/json"},
+		{"application/*;q=0.2, application/cbor;q=0.9", "application/cbor"},
+		{"application/json;q=0, */*;q=0.1", "application/xml"},
+	}
+	for _, tc := range cases {
+		req := client.Get("/book")
+		if tc.accept != "" {
+			req.Header("Accept", tc.accept)
+		}
+		req.Expect().
+			Status(200).
+			Header("Content-Type", tc.want).
+			Header("Vary", "Accept")
+	}
+
+	client.Get("/book").Header("Accept", "text/csv").Expect().
+		Status(406).
+		JSON(`{"type":"about:blank","title":"Not Acceptable","status":406,"code":"not_acceptable",
+			"details":{"available":["application/json","application/xml","application/msgpack","application/cbor","application/yaml"]}}`)
+}
+
+func TestApp_RegisterEncoderAndJSONEncoder(t *testing.T) {
+	app := TurboGo.New().
+		RegisterEncoder("text/csv", func(v any) ([]byte, error) {
+			b := v.(book)
+			return []byte("id,title\n1," + b.Title + "\n"), nil
+		}).
+		JSONEncoder(func(v any) ([]byte, error) {
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(false)
+			err := enc.Encode(v)
+			return bytes.TrimSpace(buf.Bytes()), err
+		})
+	app.Get("/book", func(c *core.Context) { c.Negotiate(200, book{ID: 1, Title: "<Dune>"}) })
+	app.Get("/json", func(c *core.Context) { c.JSON(200, book{ID: 1, Title: "<Dune>"}) })
+	app.Get("/bad", func(c *core.Context) { c.JSON(200, make(chan int)) })
+	app.Get("/unknown", func(c *core.Context) { c.Encode(200, "application/x-protobuf", nil) })
+	client := turbotest.New(t, app)
+
+	client.Get("/book").Header("Accept", "text/csv").Expect().
+		Header("Content-Type", "text/csv").
+		Body("id,title\n1,<Dune>\n")
+	client.Get("/json").Expect().Body(`{"id":1,"title":"<Dune>"}`)
+
+	// Encoding failures go through the error handler.
+	client.Get("/bad").Expect().Status(500).Header("Content-Type", "application/problem+json")
+	client.Get("/unknown").Expect().Status(500)
+}
@@ -0,0 +1,193 @@
+package test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+// counter returns a handler answering with the number of times it ran.
+func counter(n *int) core.Handler {
+	return func(c *core.Context) {
+		*n++
+		c.Ctx.Response.Header.Set("X-Custom", "yes")
+		c.Text(200, strconv.Itoa(*n))
+	}
+}
+
+func serveWithHeader(app *TurboGo.App, uri, key, value string) *fasthttp.RequestCtx {
+	core.DisableLogger = true
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetMethod("GET")
+	ctx.Request.SetRequestURI(uri)
+	ctx.Request.Header.Set(key, value)
+	app.Handler()(ctx)
+	return ctx
+}
+
+func TestResponseCache_HitAndMiss(t *testing.T) {
+	app := TurboGo.New().WithCache()
+	var n int
+	app.Get("/products", counter(&n)).Cache(time.Minute)
+
+	ctx := serve(app, "GET", "/products?b=2&a=1")
+	assert.Equal(t, "1", string(ctx.Response.Body()))
+	assert.Equal(t, "MISS", string(ctx.Response.Header.Peek("X-Cache")))
+
+	ctx = serve(app, "GET", "/products?a=1&b=2")
+	assert.Equal(t, 200, ctx.Response.StatusCode())
+	assert.Equal(t, "1", string(ctx.Response.Body()))
+	assert.Equal(t, "HIT", string(ctx.Response.Header.Peek("X-Cache")))
+	assert.Equal(t, "yes", string(ctx.Response.Header.Peek("X-Custom")))
+	assert.Equal(t, "0", string(ctx.Response.Header.Peek("Age")))
+
+	ctx = serve(app, "GET", "/products?a=2")
+	assert.Equal(t, "2", string(ctx.Response.Body()))
+
+	ctx = serve(app, "HEAD", "/products?a=2")
+	assert.Equal(t, "HIT", string(ctx.Response.Header.Peek("X-Cache")))
+	assert.Equal(t, 2, n)
+}
+
+func TestResponseCache_RequestDirectives(t *testing.T) {
+	app := TurboGo.New().WithCache()
+	var n int
+	app.Get("/feed", counter(&n)).Cache(time.Minute)
+
+	serve(app, "GET", "/feed")
+	ctx := serveWithHeader(app, "/feed", "Cache-Control", "no-cache")
+	assert.Equal(t, "2", string(ctx.Response.Body()))
+	assert.Equal(t, "MISS", string(ctx.Response.Header.Peek("X-Cache")))
+
+	ctx = serve(app, "GET", "/feed")
+	assert.Equal(t, "2", string(ctx.Response.Body()))
+
+	ctx = serveWithHeader(app, "/feed", "Cache-Control", "no-store")
+	assert.Equal(t, "3", string(ctx.Response.Body()))
+	assert.Equal(t, "BYPASS", string(ctx.Response.Header.Peek("X-Cache")))
+}
+
+func TestResponseCache_VaryAndUncacheable(t *testing.T) {
+	app := TurboGo.New().WithCache()
+	var n int
+	app.Get("/greeting", counter(&n)).Cache(time.Minute).Vary("Accept-Language")
+	app.Get("/session", func(c *core.Context) {
+		n++
+		c.Ctx.Response.Header.Set("Cache-Control", "private")
+		c.Text(200, "session")
+	}).Cache(time.Minute)
+	app.Get("/plain", counter(&n)).Cache(time.Minute).NoCache()
+
+	assert.Equal(t, "1", string(serveWithHeader(app, "/greeting", "Accept-Language", "en").Response.Body()))
+	assert.Equal(t, "2", string(serveWithHeader(app, "/greeting", "Accept-Language", "id").Response.Body()))
+	assert.Equal(t, "1", string(serveWithHeader(app, "/greeting", "Accept-Language", "en").Response.Body()))
+
+	serve(app, "GET", "/session")
+	serve(app, "GET", "/session")
+	assert.Equal(t, 4, n)
+
+	ctx := serve(app, "GET", "/plain")
+	assert.Empty(t, ctx.Response.Header.Peek("X-Cache"))
+}
+
+func TestResponseCache_Invalidation(t *testing.T) {
+	app := TurboGo.New().WithCache()
+	var users, posts int
+	app.Get("/users/:id", counter(&users)).Cache(time.Minute).Named("user")
+	app.Get("/posts/:id", counter(&posts)).Cache(time.Minute)
+
+	serve(app, "GET", "/users/1")
+	serve(app, "GET", "/users/2")
+	serve(app, "GET", "/posts/1")
+
+	assert.Equal(t, 2, app.InvalidateRoute("user"))
+	assert.Equal(t, 0, app.InvalidateRoute("missing"))
+	assert.Equal(t, "3", string(serve(app, "GET", "/users/1").Response.Body()))
+
+	assert.Equal(t, 1, app.InvalidateCache("GET /posts/"))
+	assert.Equal(t, "2", string(serve(app, "GET", "/posts/1").Response.Body()))
+	assert.Equal(t, 2, app.InvalidateCache(""))
+}
+
+func TestResponseCache_SkipsErrorsAndAborts(t *testing.T) {
+	app := TurboGo.New().WithCache()
+	var n int
+	app.Get("/flaky", func(c *core.Context) error {
+		n++
+		if n == 1 {
+			return core.NewHTTPError(503, "try again")
+		}
+		c.Text(200, strconv.Itoa(n))
+		return nil
+	}).Cache(time.Minute)
+	app.Get("/halt", func(c *core.Context) {
+		n++
+		c.Abort()
+	}, counter(&n)).Cache(time.Minute)
+
+	ctx := serve(app, "GET", "/flaky")
+	assert.Equal(t, 503, ctx.Response.StatusCode())
+	ctx = serve(app, "GET", "/flaky")
+	assert.Equal(t, 200, ctx.Response.StatusCode())
+	assert.Equal(t, "2", string(ctx.Response.Body()))
+	assert.Equal(t, "MISS", string(ctx.Response.Header.Peek("X-Cache")))
+
+	serve(app, "GET", "/halt")
+	ctx = serve(app, "GET", "/halt")
+	assert.Equal(t, "MISS", string(ctx.Response.Header.Peek("X-Cache")))
+	assert.Equal(t, 4, n)
+}
+
+func TestResponseCache_KeysOnRequestHost(t *testing.T) {
+	app := TurboGo.New().WithCache()
+	var n int
+	app.Host(":tenant.example.com").Get("/", func(c *core.Context) {
+		n++
+		c.Text(200, c.Param("tenant"))
+	}).Cache(time.Minute)
+
+	ctx := serveHost(app, "GET", "a.example.com", "/")
+	assert.Equal(t, "a", string(ctx.Response.Body()))
+	ctx = serveHost(app, "GET", "b.example.com", "/")
+	assert.Equal(t, "b", string(ctx.Response.Body()))
+	assert.Equal(t, "MISS", string(ctx.Response.Header.Peek("X-Cache")))
+
+	// The same tenant hits its own entry, whatever the port or case.
+	ctx = serveHost(app, "GET", "A.example.com:8080", "/")
+	assert.Equal(t, "a", string(ctx.Response.Body()))
+	assert.Equal(t, "HIT", string(ctx.Response.Header.Peek("X-Cache")))
+	assert.Equal(t, 2, n)
+}
+
+func TestResponseCache_Negotiate(t *testing.T) {
+	app := TurboGo.New().WithCache()
+	var n int
+	negotiate := func(c *core.Context) {
+		n++
+		c.Negotiate(200, map[string]int{"n": n})
+	}
+	app.Get("/plain", negotiate).Cache(time.Minute)
+	app.Get("/vary", negotiate).Cache(time.Minute).Vary("Accept")
+
+	// Without Vary("Accept") the negotiated response is not stored.
+	ctx := serveWithHeader(app, "/plain", "Accept", "application/yaml")
+	assert.Equal(t, "application/yaml", string(ctx.Response.Header.ContentType()))
+	ctx = serveWithHeader(app, "/plain", "Accept", "application/json")
+	assert.Equal(t, "application/json", string(ctx.Response.Header.ContentType()))
+	assert.Equal(t, "MISS", string(ctx.Response.Header.Peek("X-Cache")))
+
+	// With it, each format gets its own entry.
+	serveWithHeader(app, "/vary", "Accept", "application/yaml")
+	ctx = serveWithHeader(app, "/vary", "Accept", "application/json")
+	assert.Equal(t, "application/json", string(ctx.Response.Header.ContentType()))
+	assert.Equal(t, "MISS", string(ctx.Response.Header.Peek("X-Cache")))
+	ctx = serveWithHeader(app, "/vary", "Accept", "application/yaml")
+	assert.Equal(t, "application/yaml", string(ctx.Response.Header.ContentType()))
+	assert.Equal(t, "HIT", string(ctx.Response.Header.Peek("X-Cache")))
+	assert.Equal(t, 4, n)
+}
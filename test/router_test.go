@@ -0,0 +1,397 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/Dziqha/TurboGo/internal/router"
+	"github.com/stretchr/testify/assert"
+)
+
+func routeHandler(name string) core.Handler {
+	return func(c *core.Context) {
+		c.Text(200, name)
+	}
+}
+
+func findRoute(r *router.SingleThreadedRouter, method, path string) (*router.Route, router.Params) {
+	var ps router.Params
+	_, route := r.Find(method, path, &ps)
+	return route, ps
+}
+
+func TestRouter_StaticParamWildcard(t *testing.T) {
+	r := router.NewSingleThreadedRouter()
+	static := &router.Route{Path: "/users/new"}
+	param := &router.Route{Path: "/users/:id"}
+	nested := &router.Route{Path: "/users/:id/posts/:post"}
+	wild := &router.Route{Path: "/static/*"}
+
+	r.AddStatic("GET", "/users/new", routeHandler("static"), static)
+	r.AddParametric("GET", "/users/:id", routeHandler("param"), param)
+	r.AddParametric("GET", "/users/:id/posts/:post", routeHandler("nested"), nested)
+	r.AddWildcard("GET", "/static/", routeHandler("wild"), wild)
+
+	route, ps := findRoute(r, "GET", "/users/new")
+	assert.Same(t, static, route)
+	assert.Empty(t, ps)
+
+	route, ps = findRoute(r, "GET", "/users/42")
+	assert.Same(t, param, route)
+	assert.Equal(t, router.Params{{Key: "id", Value: "42"}}, ps)
+
+	route, ps = findRoute(r, "GET", "/users/42/posts/7")
+	assert.Same(t, nested, route)
+	assert.Equal(t, router.Params{{Key: "id", Value: "42"}, {Key: "post", Value: "7"}}, ps)
+
+	route, _ = findRoute(r, "GET", "/static/css/app.css")
+	assert.Same(t, wild, route)
+
+	route, _ = findRoute(r, "GET", "/users/42/comments")
+	assert.Nil(t, route)
+
+	route, _ = findRoute(r, "POST", "/users/new")
+	assert.Nil(t, route)
+}
+
+func TestRouter_ColonInsideSegmentIsLiteral(t *testing.T) {
+	r := router.NewSingleThreadedRouter()
+	batch := &router.Route{Path: "/v1/items:batchGet"}
+	item := &router.Route{Path: "/v1/items/:id"}
+	r.Add("GET", "/v1/items:batchGet", routeHandler("batch"), batch)
+	r.Add("GET", "/v1/items/:id", routeHandler("item"), item)
+
+	route, ps := findRoute(r, "GET", "/v1/items:batchGet")
+	assert.Same(t, batch, route)
+	assert.Empty(t, ps)
+
+	route, _ = findRoute(r, "GET", "/v1/itemsANYTHING")
+	assert.Nil(t, route)
+
+	route, ps = findRoute(r, "GET", "/v1/items/a:b*c")
+	assert.Same(t, item, route)
+	assert.Equal(t, router.Params{{Key: "id", Value: "a:b*c"}}, ps)
+}
+
+func TestRouter_SharedPrefixSplit(t *testing.T) {
+	r := router.NewSingleThreadedRouter()
+	paths := []string{"/search", "/support", "/s", "/sup", "/api/health", "/api/help"}
+	routes := make(map[string]*router.Route, len(paths))
+	for _, p := range paths {
+		routes[p] = &router.Route{Path: p}
+		r.AddStatic("GET", p, routeHandler(p), routes[p])
+	}
+
+	for _, p := range paths {
+		route, _ := findRoute(r, "GET", p)
+		assert.Same(t, routes[p], route, p)
+	}
+
+	route, _ := findRoute(r, "GET", "/su")
+	assert.Nil(t, route)
+}
+
+func TestRouter_BacktracksAcrossParamNames(t *testing.T) {
+	r := router.NewSingleThreadedRouter()
+	byID := &router.Route{Path: "/user/:id"}
+	posts := &router.Route{Path: "/user/:name/posts"}
+
+	r.AddParametric("GET", "/user/:id", routeHandler("id"), byID)
+	r.AddParametric("GET", "/user/:name/posts", routeHandler("posts"), posts)
+
+	route, ps := findRoute(r, "GET", "/user/alice/posts")
+	assert.Same(t, posts, route)
+	assert.Equal(t, router.Params{{Key: "name", Value: "alice"}}, ps)
+
+	route, ps = findRoute(r, "GET", "/user/alice")
+	assert.Same(t, byID, route)
+	assert.Equal(t, router.Params{{Key: "id", Value: "alice"}}, ps)
+}
+
+func TestRouter_ParamConstraints(t *testing.T) {
+	r := router.NewSingleThreadedRouter()
+	byName := &router.Route{Path: "/user/:name"}
+	byID := &router.Route{Path: "/user/:id<int>"}
+	file := &router.Route{Path: `/file/:name<regex(^[a-z]+\.txt$)>`}
+	slug := &router.Route{Path: "/post/:slug<minlen(3);alpha>"}
+
+	// Registered before the constrained route on purpose: constrained
+	// params are still tried first.
+	r.AddParametric("GET", byName.Path, routeHandler("name"), byName)
+	r.AddParametric("GET", byID.Path, routeHandler("id"), byID)
+	r.AddParametric("GET", file.Path, routeHandler("file"), file)
+	r.AddParametric("GET", slug.Path, routeHandler("slug"), slug)
+
+	route, ps := findRoute(r, "GET", "/user/42")
+	assert.Same(t, byID, route)
+	assert.Equal(t, router.Params{{Key: "id", Value: "42"}}, ps)
+
+	route, ps = findRoute(r, "GET", "/user/alice")
+	assert.Same(t, byName, route)
+	assert.Equal(t, router.Params{{Key: "name", Value: "alice"}}, ps)
+
+	route, _ = findRoute(r, "GET", "/file/notes.txt")
+	assert.Same(t, file, route)
+	route, _ = findRoute(r, "GET", "/file/Notes.md")
+	assert.Nil(t, route)
+
+	route, _ = findRoute(r, "GET", "/post/go")
+	assert.Nil(t, route)
+	route, _ = findRoute(r, "GET", "/post/golang")
+	assert.Same(t, slug, route)
+}
+
+func TestRouter_OptionalParams(t *testing.T) {
+	r := router.NewSingleThreadedRouter()
+	list := &router.Route{Path: "/posts/:page<int>?"}
+	r.AddParametric("GET", list.Path, routeHandler("list"), list)
+
+	route, ps := findRoute(r, "GET", "/posts/3")
+	assert.Same(t, list, route)
+	assert.Equal(t, router.Params{{Key: "page", Value: "3"}}, ps)
+
+	route, ps = findRoute(r, "GET", "/posts")
+	assert.Same(t, list, route)
+	assert.Empty(t, ps)
+
+	route, _ = findRoute(r, "GET", "/posts/abc")
+	assert.Nil(t, route)
+}
+
+func TestRouter_InvalidConstraintPanics(t *testing.T) {
+	r := router.NewSingleThreadedRouter()
+	assert.Panics(t, func() {
+		r.AddParametric("GET", "/user/:id<bogus>", routeHandler("x"), &router.Route{})
+	})
+	assert.Panics(t, func() {
+		r.AddParametric("GET", "/user/:id<int", routeHandler("x"), &router.Route{})
+	})
+}
+
+func TestRouter_NamedWildcards(t *testing.T) {
+	r := router.NewSingleThreadedRouter()
+	files := &router.Route{Path: "/static/*filepath"}
+	tree := &router.Route{Path: "/repos/:owner/*path"}
+	any := &router.Route{Path: "/any/*"}
+	r.Add("GET", files.Path, routeHandler("files"), files)
+	r.Add("GET", tree.Path, routeHandler("tree"), tree)
+	r.Add("GET", any.Path, routeHandler("any"), any)
+
+	route, ps := findRoute(r, "GET", "/static/css/app.css")
+	assert.Same(t, files, route)
+	assert.Equal(t, router.Params{{Key: "filepath", Value: "css/app.css"}}, ps)
+
+	route, ps = findRoute(r, "GET", "/static/")
+	assert.Same(t, files, route)
+	assert.Equal(t, router.Params{{Key: "filepath", Value: ""}}, ps)
+
+	route, ps = findRoute(r, "GET", "/repos/golang/src/net/http")
+	assert.Same(t, tree, route)
+	assert.Equal(t, router.Params{{Key: "owner", Value: "golang"}, {Key: "path", Value: "src/net/http"}}, ps)
+
+	route, ps = findRoute(r, "GET", "/any/thing")
+	assert.Same(t, any, route)
+	assert.Equal(t, router.Params{{Key: "*", Value: "thing"}}, ps)
+
+	static, wildcard, parametric := r.GetRouteCount()
+	assert.Equal(t, [3]int{0, 3, 0}, [3]int{static, wildcard, parametric})
+
+	assert.Panics(t, func() {
+		r.Add("GET", "/bad/*rest/more", routeHandler("bad"), &router.Route{})
+	})
+}
+
+// Precedence at every segment is static > param > wildcard, and the router
+// backtracks when a more specific branch fails further down.
+func TestRouter_Precedence(t *testing.T) {
+	r := router.NewSingleThreadedRouter()
+	routes := map[string]*router.Route{}
+	for _, p := range []string{
+		"/repos/new",
+		"/repos/:owner",
+		"/repos/:owner/settings",
+		"/repos/:owner/*path",
+		"/repos/*rest",
+	} {
+		routes[p] = &router.Route{Path: p}
+		r.Add("GET", p, routeHandler(p), routes[p])
+	}
+
+	cases := map[string]string{
+		"/repos/new":             "/repos/new",
+		"/repos/golang":          "/repos/:owner",
+		"/repos/golang/settings": "/repos/:owner/settings",
+		"/repos/golang/go/src":   "/repos/:owner/*path",
+		"/repos/new/settings":    "/repos/:owner/settings",
+		"/repos/":                "/repos/*rest",
+	}
+	for path, want := range cases {
+		route, _ := findRoute(r, "GET", path)
+		if assert.NotNil(t, route, path) {
+			assert.Equal(t, want, route.Path, path)
+		}
+	}
+}
+
+func TestRouter_CleanPath(t *testing.T) {
+	cases := map[string]string{
+		"":                 "/",
+		"/":                "/",
+		"users":            "/users",
+		"//users/../admin": "/admin",
+		"/a/./b/":          "/a/b/",
+		"/a/b/..":          "/a",
+		"/../../x":         "/x",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, router.CleanPath(in), in)
+	}
+}
+
+func TestRouter_FindDoesNotAllocate(t *testing.T) {
+	r := buildBenchRouter()
+	ps := router.AcquireParams()
+	defer router.ReleaseParams(ps)
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		*ps = (*ps)[:0]
+		r.Find("GET", "/api/v1/resource199/42/items/7", ps)
+	})
+	assert.Zero(t, allocs)
+}
+
+// ===== BENCHMARKS: radix tree vs. the previous linear router =====
+
+const benchParamRoutes = 400
+
+func benchPatterns() []string {
+	patterns := make([]string, 0, benchParamRoutes)
+	for i := 0; i < benchParamRoutes/2; i++ {
+		patterns = append(patterns,
+			fmt.Sprintf("/api/v1/resource%d/:id", i),
+			fmt.Sprintf("/api/v1/resource%d/:id/items/:item", i),
+		)
+	}
+	return patterns
+}
+
+func buildBenchRouter() *router.SingleThreadedRouter {
+	r := router.NewSingleThreadedRouter()
+	h := routeHandler("ok")
+	for _, p := range benchPatterns() {
+		r.AddParametric("GET", p, h, &router.Route{Path: p, Method: "GET"})
+	}
+	r.AddWildcard("GET", "/static/", h, &router.Route{Path: "/static/*", Method: "GET"})
+	return r
+}
+
+func buildLinearRouter() *linearRouter {
+	r := &linearRouter{static: map[string]core.Handler{}}
+	h := routeHandler("ok")
+	for _, p := range benchPatterns() {
+		r.addParametric(p, h)
+	}
+	r.addWildcard("/static/", h)
+	return r
+}
+
+var benchLookups = []string{
+	"/api/v1/resource0/1",
+	"/api/v1/resource99/42/items/7",
+	"/api/v1/resource199/42/items/7",
+	"/static/js/app.js",
+}
+
+func BenchmarkRouter_Radix400Params(b *testing.B) {
+	r := buildBenchRouter()
+	ps := router.AcquireParams()
+	defer router.ReleaseParams(ps)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		*ps = (*ps)[:0]
+		r.Find("GET", benchLookups[i%len(benchLookups)], ps)
+	}
+}
+
+func BenchmarkRouter_Linear400Params(b *testing.B) {
+	r := buildLinearRouter()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.find(benchLookups[i%len(benchLookups)])
+	}
+}
+
+// linearRouter reproduces the lookup strategy SingleThreadedRouter used
+// before the radix tree: a static map, then a linear scan over wildcard
+// prefixes and parametric patterns split on every request.
+type linearRouter struct {
+	static     map[string]core.Handler
+	wildcards  []linearWildcard
+	parametric []linearParametric
+}
+
+type linearWildcard struct {
+	prefix  string
+	handler core.Handler
+}
+
+type linearParametric struct {
+	handler  core.Handler
+	params   []string
+	segments []string
+}
+
+func (r *linearRouter) addWildcard(prefix string, h core.Handler) {
+	r.wildcards = append(r.wildcards, linearWildcard{prefix: prefix, handler: h})
+}
+
+func (r *linearRouter) addParametric(pattern string, h core.Handler) {
+	segments := strings.Split(pattern, "/")
+	params := make([]string, 0, 2)
+	for _, s := range segments {
+		if len(s) > 0 && s[0] == ':' {
+			params = append(params, s[1:])
+		}
+	}
+	r.parametric = append(r.parametric, linearParametric{handler: h, params: params, segments: segments})
+}
+
+func (r *linearRouter) find(path string) (core.Handler, map[string]string) {
+	if h := r.static[path]; h != nil {
+		return h, nil
+	}
+	for _, w := range r.wildcards {
+		if strings.HasPrefix(path, w.prefix) {
+			return w.handler, nil
+		}
+	}
+	for i := range r.parametric {
+		p := &r.parametric[i]
+		parts := strings.Split(path, "/")
+		if len(parts) != len(p.segments) {
+			continue
+		}
+		values := make(map[string]string, len(p.params))
+		matched := true
+		idx := 0
+		for j, seg := range p.segments {
+			if len(seg) > 0 && seg[0] == ':' {
+				values[p.params[idx]] = parts[j]
+				idx++
+			} else if seg != parts[j] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return p.handler, values
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,127 @@
+package test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func listUsers(c *core.Context) { c.Text(200, "users") }
+
+func TestApp_RoutesIntrospection(t *testing.T) {
+	app := TurboGo.New()
+	app.Get("/users", listUsers).Named("users.list").Meta("auth", "public")
+	app.Add([]string{"PUT", "PATCH"}, "/users/:id", listUsers)
+
+	routes := app.Routes()
+	assert.Len(t, routes, 3)
+	assert.Equal(t, "GET", routes[0].Method)
+	assert.Equal(t, "/users", routes[0].Path)
+	assert.Equal(t, "users.list", routes[0].Name)
+	assert.Equal(t, []string{"test.listUsers"}, routes[0].Handlers)
+	assert.Equal(t, map[string]any{"auth": "public"}, routes[0].Metadata)
+	assert.Equal(t, "PUT", routes[1].Method)
+	assert.Equal(t, "PATCH", routes[2].Method)
+
+	assert.Same(t, app.Route("/users/:id"), app.Route("/users/:id"))
+	assert.Equal(t, "/users/:id", app.Route("/users/:id").Path)
+	assert.Nil(t, app.Route("/nope"))
+
+	for i := 0; i < 5; i++ {
+		serve(app, "GET", "/users")
+	}
+	assert.Len(t, app.Routes(), 3)
+}
+
+func TestApp_RoutesHandlerAndTable(t *testing.T) {
+	app := TurboGo.New()
+	app.Post("/b", listUsers)
+	app.Get("/a", listUsers).Named("a")
+	app.Get("/debug/routes", app.RoutesHandler())
+
+	ctx := serve(app, "GET", "/debug/routes")
+	assert.Equal(t, 200, ctx.Response.StatusCode())
+
+	var infos []TurboGo.RouteInfo
+	assert.NoError(t, json.Unmarshal(ctx.Response.Body(), &infos))
+	assert.Len(t, infos, 3)
+	assert.Equal(t, "/a", infos[0].Path)
+	assert.Equal(t, "/b", infos[1].Path)
+	assert.Equal(t, "/debug/routes", infos[2].Path)
+
+	table := TurboGo.RouteTable(app.Routes())
+	lines := strings.Split(strings.TrimSpace(table), "\n")
+	assert.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], "METHOD"))
+	assert.Contains(t, lines[2], "/a")
+	assert.Contains(t, lines[2], "test.listUsers")
+}
+
+func TestApp_StrictRoutingConflicts(t *testing.T) {
+	cases := []struct {
+		name   string
+		first  string
+		second string
+		reason string
+	}{
+		{"duplicate static", "/users", "/users", "duplicate route"},
+		{"param names", "/user/:id", "/user/:name", `parameter ":name" conflicts with ":id" at the same position`},
+		{"param names deeper", "/user/:id", "/user/:name/posts", `parameter ":name" conflicts with ":id" at the same position`},
+		{"wildcard names", "/files/*path", "/files/*rest", `wildcard "*rest" conflicts with "*path" at the same position`},
+		{"optional variant", "/posts", "/posts/:page?", "patterns match the same paths"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := TurboGo.New().StrictRouting(true)
+			app.Get(tc.first, listUsers)
+
+			defer func() {
+				rec := recover()
+				err, ok := rec.(*TurboGo.RouteConflictError)
+				if !assert.True(t, ok, "expected *RouteConflictError, got %v", rec) {
+					return
+				}
+				assert.Equal(t, tc.reason, err.Reason)
+				assert.Equal(t, tc.first, err.Existing.Path)
+				assert.Contains(t, err.Error(), "routes_test.go:")
+			}()
+			app.Get(tc.second, listUsers)
+		})
+	}
+}
+
+func TestApp_NonConflictingRoutes(t *testing.T) {
+	app := TurboGo.New().StrictRouting(true)
+	assert.NotPanics(t, func() {
+		app.Get("/user/:id", listUsers)
+		app.Post("/user/:id", listUsers)
+		app.Get("/user/:id/posts", listUsers)
+		app.Get("/user/:id<int>/orders", listUsers)
+		app.Get("/user/:slug<alpha>", listUsers)
+		app.Get("/files/*path", listUsers)
+		app.Get("/files/readme", listUsers)
+	})
+}
+
+func TestApp_ConflictWarnsWhenNotStrict(t *testing.T) {
+	core.DisableLogger = true
+	app := TurboGo.New()
+	app.Get("/users", func(c *core.Context) { c.Text(200, "first") })
+	assert.NotPanics(t, func() {
+		app.Get("/users", func(c *core.Context) { c.Text(200, "second") })
+	})
+
+	ctx := serve(app, "GET", "/users")
+	assert.Equal(t, "second", string(ctx.Response.Body()))
+
+	// A renamed wildcard replaces the route under its own name.
+	app.Get("/files/*path", func(c *core.Context) { c.Text(200, "path="+c.Param("path")) })
+	app.Get("/files/*rest", func(c *core.Context) { c.Text(200, "rest="+c.Param("rest")) })
+	ctx = serve(app, "GET", "/files/a/b.txt")
+	assert.Equal(t, "rest=a/b.txt", string(ctx.Response.Body()))
+}
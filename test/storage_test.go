@@ -0,0 +1,92 @@
+package test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/internal/persist"
+	"github.com/Dziqha/TurboGo/internal/pubsub"
+	"github.com/Dziqha/TurboGo/internal/queue"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorage_AppDataDirs(t *testing.T) {
+	dirA, dirB := t.TempDir(), t.TempDir()
+	a := TurboGo.New().WithQueue(TurboGo.StorageOptions{DataDir: dirA}).WithPubsub(TurboGo.StorageOptions{DataDir: dirA})
+	b := TurboGo.New(TurboGo.Config{DataDir: dirB}).WithQueue()
+	defer a.Shutdown(context.Background())
+	defer b.Shutdown(context.Background())
+
+	assert.NoError(t, a.EngineCtx.Queue.EnqueueAll("mail", []byte("a")))
+	assert.NoError(t, a.EngineCtx.Pubsub.PublishAll("news", []byte("a")))
+	assert.NoError(t, b.EngineCtx.Queue.EnqueueAll("mail", []byte("b")))
+
+	assert.FileExists(t, filepath.Join(dirA, "queue.json"))
+	assert.FileExists(t, filepath.Join(dirA, "pubsub.json"))
+	assert.FileExists(t, filepath.Join(dirB, "queue.json"))
+	assert.NoFileExists(t, filepath.Join(dirB, "pubsub.json"))
+}
+
+func TestStorage_FsyncPolicies(t *testing.T) {
+	for _, opts := range []persist.Options{
+		{Sync: persist.SyncBatch, BatchSize: 2},
+		{Sync: persist.SyncInterval, SyncInterval: 10 * time.Millisecond},
+	} {
+		file := filepath.Join(t.TempDir(), "queue.json")
+		q, err := queue.NewPersistentWithOptions(file, opts)
+		assert.NoError(t, err)
+		for i := 0; i < 3; i++ {
+			assert.NoError(t, q.Enqueue("jobs", []byte{byte('a' + i)}))
+		}
+		q.Close()
+
+		reopened, err := queue.NewPersistent(file)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, reopened.GetQueueSize("jobs"))
+		reopened.Close()
+	}
+}
+
+func TestStorage_MaxFileSizeCompactsEarly(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "pubsub.json")
+	bus, err := pubsub.NewPersistentWithOptions(file, persist.Options{
+		Retention:   time.Nanosecond,
+		MaxFileSize: 512,
+	})
+	assert.NoError(t, err)
+	defer bus.Close()
+
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, bus.Publish("news", []byte("an old message that will expire")))
+	}
+
+	assert.Eventually(t, func() bool {
+		info, err := os.Stat(file)
+		return err == nil && info.Size() < 512
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestStorage_MaxFileSizeBacksOffWhenCompactionKeepsEverything(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "log.json"))
+	assert.NoError(t, err)
+	defer f.Close()
+
+	s := persist.NewSyncer(persist.Options{Sync: persist.SyncBatch, BatchSize: 1000, MaxFileSize: 100})
+	s.Reset(f)
+	full, _ := s.Wrote(f, 101)
+	assert.True(t, full, "a fresh file over the limit compacts")
+
+	// Compaction kept every record, so the file is still over the limit.
+	f.Write(make([]byte, 150))
+	s.Reset(f)
+	for i := 0; i < 50; i++ {
+		full, _ = s.Wrote(f, 1)
+		assert.False(t, full, "write %d after a compaction that did not shrink the file", i)
+	}
+	full, _ = s.Wrote(f, 1)
+	assert.True(t, full, "compacts again after growing by half the limit")
+}
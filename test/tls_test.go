@@ -0,0 +1,228 @@
+package test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newCert issues a certificate for cn, signed by parent or self-signed.
+func newCert(t *testing.T, cn string, parent *testCert, isCA bool) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cn},
+		DNSNames:              []string{cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	signer, signerKey := tmpl, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func (c *testCert) files(t *testing.T, dir string) (string, string) {
+	certFile := filepath.Join(dir, c.cert.Subject.CommonName+".crt")
+	keyFile := filepath.Join(dir, c.cert.Subject.CommonName+".key")
+	if err := os.WriteFile(certFile, c.certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, c.keyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+// startTLS runs app.ListenTLSWithConfig in the background until the test ends.
+func startTLS(t *testing.T, app *TurboGo.App, addr string, cfg TurboGo.TLSConfig) {
+	core.DisableLogger = true
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- app.ListenTLSWithConfig(ctx, addr, cfg) }()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+// peerName dials addr with serverName and returns the served certificate's CN.
+func peerName(t *testing.T, addr, serverName string) string {
+	var conn *tls.Conn
+	var err error
+	for i := 0; i < 50; i++ {
+		conn, err = tls.Dial("tcp", addr, &tls.Config{ServerName: serverName, InsecureSkipVerify: true})
+		if err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+}
+
+func TestTLS_SNIAndInMemoryCerts(t *testing.T) {
+	api, www := newCert(t, "api.test", nil, false), newCert(t, "www.test", nil, false)
+	app := TurboGo.New(TurboGo.Config{DisableBanner: true})
+	app.Get("/", func(c *core.Context) {
+		if c.IsTLS() {
+			c.Text(200, "secure")
+		}
+	})
+
+	addr := freeAddr(t)
+	startTLS(t, app, addr, TurboGo.TLSConfig{Certificates: []TurboGo.TLSCertificate{
+		{CertPEM: api.certPEM, KeyPEM: api.keyPEM},
+		{CertPEM: www.certPEM, KeyPEM: www.keyPEM},
+	}})
+
+	assert.Equal(t, "api.test", peerName(t, addr, "api.test"))
+	assert.Equal(t, "www.test", peerName(t, addr, "www.test"))
+	assert.Equal(t, "api.test", peerName(t, addr, "other.test"))
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	resp, err := client.Get("https://" + addr + "/")
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "secure", string(body))
+}
+
+func TestTLS_ClientCertificates(t *testing.T) {
+	ca := newCert(t, "test-ca", nil, true)
+	server := newCert(t, "localhost", ca, false)
+	client := newCert(t, "alice", ca, false)
+	caFile, _ := ca.files(t, t.TempDir())
+
+	app := TurboGo.New(TurboGo.Config{DisableBanner: true})
+	app.Get("/whoami", func(c *core.Context) {
+		c.Text(200, c.ClientCertificate().Subject.CommonName)
+	})
+	addr := freeAddr(t)
+	startTLS(t, app, addr, TurboGo.TLSConfig{
+		Certificates: []TurboGo.TLSCertificate{{CertPEM: server.certPEM, KeyPEM: server.keyPEM}},
+		ClientCAFile: caFile,
+	})
+	peerName(t, addr, "localhost") // wait for the listener
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	pair, _ := tls.X509KeyPair(client.certPEM, client.keyPEM)
+	withCert := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		RootCAs:      roots,
+		Certificates: []tls.Certificate{pair},
+	}}}
+	resp, err := withCert.Get("https://localhost:" + portOf(addr) + "/whoami")
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "alice", string(body))
+
+	anonymous := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+	_, err = anonymous.Get("https://localhost:" + portOf(addr) + "/whoami")
+	assert.Error(t, err)
+}
+
+func TestTLS_HotReload(t *testing.T) {
+	dir := t.TempDir()
+	first := newCert(t, "first.test", nil, false)
+	certFile, keyFile := first.files(t, dir)
+
+	app := TurboGo.New(TurboGo.Config{DisableBanner: true})
+	addr := freeAddr(t)
+	startTLS(t, app, addr, TurboGo.TLSConfig{
+		Certificates:   []TurboGo.TLSCertificate{{CertFile: certFile, KeyFile: keyFile}},
+		ReloadInterval: 10 * time.Millisecond,
+	})
+	assert.Equal(t, "first.test", peerName(t, addr, ""))
+
+	second := newCert(t, "second.test", nil, false)
+	later := time.Now().Add(time.Second)
+	assert.NoError(t, os.WriteFile(certFile, second.certPEM, 0o600))
+	assert.NoError(t, os.WriteFile(keyFile, second.keyPEM, 0o600))
+	os.Chtimes(certFile, later, later)
+	os.Chtimes(keyFile, later, later)
+
+	assert.Eventually(t, func() bool {
+		return peerName(t, addr, "") == "second.test"
+	}, time.Second, 20*time.Millisecond)
+}
+
+func TestTLS_RedirectHTTP(t *testing.T) {
+	cert := newCert(t, "localhost", nil, false)
+	app := TurboGo.New(TurboGo.Config{DisableBanner: true})
+	addr, httpAddr := freeAddr(t), freeAddr(t)
+	startTLS(t, app, addr, TurboGo.TLSConfig{
+		Certificates: []TurboGo.TLSCertificate{{CertPEM: cert.certPEM, KeyPEM: cert.keyPEM}},
+		RedirectHTTP: httpAddr,
+	})
+	peerName(t, addr, "localhost")
+
+	noFollow := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := noFollow.Get("http://" + httpAddr + "/users?page=2")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, 301, resp.StatusCode)
+	assert.Equal(t, "https://127.0.0.1:"+portOf(addr)+"/users?page=2", resp.Header.Get("Location"))
+}
+
+func TestTLS_Errors(t *testing.T) {
+	app := TurboGo.New(TurboGo.Config{DisableBanner: true})
+	err := app.ListenTLSWithConfig(context.Background(), freeAddr(t), TurboGo.TLSConfig{})
+	assert.ErrorContains(t, err, "no certificates")
+
+	err = app.ListenTLS(context.Background(), freeAddr(t), "missing.crt", "missing.key")
+	assert.ErrorContains(t, err, "missing.crt")
+}
+
+func portOf(addr string) string {
+	_, port, _ := net.SplitHostPort(addr)
+	return port
+}
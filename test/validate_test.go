@@ -0,0 +1,183 @@
+package test
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/Dziqha/TurboGo/turbotest"
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	City string `json:"city" validate:"required"`
+	Zip  string `json:"zip" validate:"omitempty,numeric,len=5"`
+}
+
+type lineItem struct {
+	SKU string `json:"sku" validate:"required,alphanum"`
+	Qty int    `json:"qty" validate:"gte=1,lte=99"`
+}
+
+type signup struct {
+	Tenant  string     `header:"X-Tenant" validate:"required"`
+	Name    string     `json:"name" validate:"required,min=3"`
+	Email   string     `json:"email" validate:"required,email"`
+	Plan    string     `json:"plan" validate:"oneof=free pro"`
+	Tags    []string   `json:"tags" validate:"max=3,dive,min=2"`
+	Address *address   `json:"address" validate:"required"`
+	Items   []lineItem `json:"items" validate:"required"`
+	Note    *string    `json:"note" validate:"omitempty,max=10"`
+}
+
+func signupApp() *TurboGo.App {
+	app := TurboGo.New()
+	app.Post("/signup", func(c *core.Context) error {
+		var in signup
+		if err := c.BindAndValidate(&in); err != nil {
+			return err
+		}
+		c.Text(201, in.Name)
+		return nil
+	})
+	return app
+}
+
+func TestContext_BindAndValidate(t *testing.T) {
+	client := turbotest.New(t, signupApp())
+
+	client.Post("/signup").Header("X-Tenant", "acme").
+		JSON(map[string]any{
+			"name": "Ada", "email": "ada@example.com", "plan": "pro", "tags": []string{"go"},
+			"address": map[string]any{"city": "London", "zip": "12345"},
+			"items":   []map[string]any{{"sku": "A1", "qty": 2}},
+		}).
+		Expect().
+		Status(201).
+		Body("Ada")
+
+	client.Post("/signup").
+		JSON(map[string]any{
+			"name": "Al", "email": "not-an-email", "plan": "gold", "tags": []string{"go", "x"},
+			"address": map[string]any{"zip": "12"},
+			"items":   []map[string]any{{"sku": "A1", "qty": 1}, {"sku": "B-2", "qty": 0}},
+		}).
+		Expect().
+		Status(422).
+		Header("Content-Type", "application/problem+json").
+		JSON(`{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"validation failed","code":"validation_failed","details":[
+			{"field":"X-Tenant","source":"header","rule":"required","message":"is required"},
+			{"field":"name","source":"body","rule":"min","param":"3","message":"must be at least 3 characters long"},
+			{"field":"email","source":"body","rule":"email","message":"must be a valid email address"},
+			{"field":"plan","source":"body","rule":"oneof","param":"free pro","message":"must be one of free pro"},
+			{"field":"tags[1]","source":"body","rule":"min","param":"2","message":"must be at least 2 characters long"},
+			{"field":"address.city","source":"body","rule":"required","message":"is required"},
+			{"field":"address.zip","source":"body","rule":"len","param":"5","message":"must be exactly 5 characters long"},
+			{"field":"items[1].sku","source":"body","rule":"alphanum","message":"must contain only letters and digits"},
+			{"field":"items[1].qty","source":"body","rule":"gte","param":"1","message":"must be at least 1"}
+		]}`)
+
+	// Binding errors come first and keep their 400.
+	client.Post("/signup").JSON(map[string]any{"name": 7}).
+		Expect().
+		Status(400)
+}
+
+func TestApp_ValidationRulesAndMessages(t *testing.T) {
+	slug := regexp.MustCompile(`^[a-z0-9-]+$`)
+	app := TurboGo.New().
+		ValidationRule("slug", func(v reflect.Value, _ string) bool {
+			return slug.MatchString(v.String())
+		}, "must be a slug").
+		ValidationMessages("id", map[string]string{
+			"required":   "wajib diisi",
+			"min.string": "minimal {param} karakter",
+		})
+
+	type post struct {
+		Slug  string `json:"slug" validate:"slug"`
+		Title string `json:"title" validate:"required,min=5"`
+		Body  string `json:"body" validate:"required"`
+	}
+	app.Post("/posts", func(c *core.Context) error {
+		var in post
+		return c.BindAndValidate(&in)
+	})
+	client := turbotest.New(t, app)
+	body := map[string]any{"slug": "Hello World", "title": "Hi"}
+
+	client.Post("/posts").JSON(body).
+		Expect().
+		Status(422).
+		BodyContains(`"message":"must be a slug"`).
+		BodyContains(`"message":"must be at least 5 characters long"`).
+		BodyContains(`"message":"is required"`)
+
+	// The closest language wins per message, falling back to English.
+	client.Post("/posts").Header("Accept-Language", "fr;q=0.9, id-ID, en;q=0.5").JSON(body).
+		Expect().
+		Status(422).
+		BodyContains(`"message":"must be a slug"`).
+		BodyContains(`"message":"minimal 5 karakter"`).
+		BodyContains(`"message":"wajib diisi"`)
+
+	assert.PanicsWithValue(t, `validate: rule "required" is reserved`, func() {
+		app.ValidationRule("required", func(reflect.Value, string) bool { return true }, "")
+	})
+}
+
+func TestValidator_Validate(t *testing.T) {
+	v := core.NewValidator()
+
+	type config struct {
+		Port    int               `validate:"gt=0,lt=65536"`
+		Workers []int             `validate:"required,dive,min=1"`
+		Labels  map[string]string `validate:"dive,max=3"`
+		Owner   string            `validate:"uuid"`
+	}
+	err := v.Validate(config{Port: 70000, Labels: map[string]string{"a": "ok", "b": "long"}, Owner: "me"})
+
+	var validationErr *core.ValidationError
+	if assert.True(t, errors.As(err, &validationErr)) {
+		var fields []string
+		for _, f := range validationErr.Fields {
+			fields = append(fields, f.Field+":"+f.Rule)
+		}
+		assert.Equal(t, []string{"Port:lt", "Workers:required", "Labels[b]:max", "Owner:uuid"}, fields)
+	}
+	assert.True(t, strings.HasPrefix(err.Error(), "validate: Port must be less than 65536; "))
+
+	assert.NoError(t, v.Validate(&config{Port: 80, Workers: []int{1}, Owner: "0b6f0c4e-8d53-4f3c-9a57-5f7b0b1e9d2a"}))
+	assert.Error(t, v.Validate("not a struct"))
+
+	type typo struct {
+		Name string `validate:"requird"`
+	}
+	assert.PanicsWithValue(t, `validate: unknown rule "requird" on field Name`, func() {
+		v.Validate(typo{Name: "x"})
+	})
+}
+
+func TestApp_MountKeepsValidationRules(t *testing.T) {
+	sub := TurboGo.New().ValidationRule("even", func(v reflect.Value, _ string) bool {
+		return v.Int()%2 == 0
+	}, "must be even")
+	sub.Get("/n", func(c *core.Context) error {
+		var in struct {
+			N int `query:"n" validate:"even"`
+		}
+		return c.BindAndValidate(&in)
+	})
+
+	app := TurboGo.New()
+	app.Mount("/sub", sub)
+
+	turbotest.New(t, app).Get("/sub/n?n=3").
+		Expect().
+		Status(422).
+		BodyContains(`{"field":"n","source":"query","rule":"even","message":"must be even"}`)
+}
@@ -0,0 +1,248 @@
+package TurboGo
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/valyala/fasthttp"
+)
+
+// ===== TLS =====
+
+// TLSCertificate is a certificate and its private key, either as PEM files
+// or as PEM data already in memory.
+type TLSCertificate struct {
+	CertFile string
+	KeyFile  string
+	CertPEM  []byte
+	KeyPEM   []byte
+}
+
+// TLSConfig configures ListenTLSWithConfig.
+type TLSConfig struct {
+	// Certificates served to clients. With several, the one matching the
+	// SNI server name is chosen; the first is the fallback.
+	Certificates []TLSCertificate
+
+	// Client certificate verification (mTLS). Setting ClientCAFile or
+	// ClientCAs without ClientAuth requires and verifies a client cert.
+	ClientCAFile string
+	ClientCAs    *x509.CertPool
+	ClientAuth   tls.ClientAuthType
+
+	// MinVersion defaults to TLS 1.2.
+	MinVersion uint16
+
+	// ReloadInterval, when set, checks certificate files for changes and
+	// reloads them without a restart. A failed reload keeps the old certs.
+	ReloadInterval time.Duration
+
+	// RedirectHTTP, when set, also listens for plain HTTP on this address
+	// and redirects every request to HTTPS.
+	RedirectHTTP string
+}
+
+// ListenTLS is Listen over HTTPS with a single certificate.
+func (a *App) ListenTLS(ctx context.Context, addr, certFile, keyFile string) error {
+	return a.ListenTLSWithConfig(ctx, addr, TLSConfig{
+		Certificates: []TLSCertificate{{CertFile: certFile, KeyFile: keyFile}},
+	})
+}
+
+// ListenTLSWithConfig serves HTTPS on addr with SNI, optional client
+// certificate verification, certificate hot-reload and an HTTP redirect
+// listener. It stops like Listen.
+//
+//	app.ListenTLSWithConfig(ctx, ":443", TurboGo.TLSConfig{
+//		Certificates: []TurboGo.TLSCertificate{
+//			{CertFile: "api.crt", KeyFile: "api.key"},
+//			{CertFile: "www.crt", KeyFile: "www.key"},
+//		},
+//		ReloadInterval: time.Minute,
+//		RedirectHTTP:   ":80",
+//	})
+func (a *App) ListenTLSWithConfig(ctx context.Context, addr string, cfg TLSConfig) error {
+	if a.config.Prefork {
+		return errors.New(preforkNotSupported)
+	}
+	tlsConfig, store, err := cfg.build()
+	if err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp4", addr)
+	if err != nil {
+		return err
+	}
+	bindings := []binding{{a.newServer(), tls.NewListener(ln, tlsConfig)}}
+
+	if cfg.RedirectHTTP != "" {
+		rln, err := net.Listen("tcp4", cfg.RedirectHTTP)
+		if err != nil {
+			ln.Close()
+			return err
+		}
+		bindings = append(bindings, binding{httpsRedirectServer(ln.Addr()), rln})
+	}
+
+	if cfg.ReloadInterval > 0 {
+		go store.watch(cfg.ReloadInterval, a.stopped, a.logger)
+	}
+
+	a.printStartup(listenURL("https", addr))
+	return a.serve(ctx, bindings...)
+}
+
+func (cfg TLSConfig) build() (*tls.Config, *certStore, error) {
+	if len(cfg.Certificates) == 0 {
+		return nil, nil, errors.New("tls: no certificates")
+	}
+	store := &certStore{sources: cfg.Certificates}
+	if err := store.load(); err != nil {
+		return nil, nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		GetCertificate: store.getCertificate,
+		MinVersion:     cfg.MinVersion,
+		ClientAuth:     cfg.ClientAuth,
+		ClientCAs:      cfg.ClientCAs,
+	}
+	if tlsConfig.MinVersion == 0 {
+		tlsConfig.MinVersion = tls.VersionTLS12
+	}
+	if cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tls: client CA: %w", err)
+		}
+		if tlsConfig.ClientCAs == nil {
+			tlsConfig.ClientCAs = x509.NewCertPool()
+		}
+		if !tlsConfig.ClientCAs.AppendCertsFromPEM(pem) {
+			return nil, nil, fmt.Errorf("tls: client CA %s: no certificates found", cfg.ClientCAFile)
+		}
+	}
+	if tlsConfig.ClientCAs != nil && cfg.ClientAuth == tls.NoClientCert {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, store, nil
+}
+
+// certStore holds the served certificates and swaps them on reload.
+type certStore struct {
+	sources  []TLSCertificate
+	certs    atomic.Pointer[[]*tls.Certificate]
+	modTimes []time.Time // of the source files at the last load
+}
+
+func (s *certStore) load() error {
+	certs := make([]*tls.Certificate, 0, len(s.sources))
+	for _, src := range s.sources {
+		certPEM, keyPEM := src.CertPEM, src.KeyPEM
+		if src.CertFile != "" {
+			var err error
+			if certPEM, err = os.ReadFile(src.CertFile); err != nil {
+				return fmt.Errorf("tls: %w", err)
+			}
+			if keyPEM, err = os.ReadFile(src.KeyFile); err != nil {
+				return fmt.Errorf("tls: %w", err)
+			}
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return fmt.Errorf("tls: %w", err)
+		}
+		certs = append(certs, &cert)
+	}
+	s.modTimes = s.fileTimes()
+	s.certs.Store(&certs)
+	return nil
+}
+
+func (s *certStore) getCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	certs := *s.certs.Load()
+	if len(certs) > 1 {
+		for _, cert := range certs {
+			if hello.SupportsCertificate(cert) == nil {
+				return cert, nil
+			}
+		}
+	}
+	return certs[0], nil
+}
+
+func (s *certStore) fileTimes() []time.Time {
+	var times []time.Time
+	for _, src := range s.sources {
+		for _, name := range []string{src.CertFile, src.KeyFile} {
+			if name == "" {
+				continue
+			}
+			var t time.Time
+			if info, err := os.Stat(name); err == nil {
+				t = info.ModTime()
+			}
+			times = append(times, t)
+		}
+	}
+	return times
+}
+
+// watch reloads the certificates whenever a source file changes, until stop
+// is closed. Failed reloads are logged to log.
+func (s *certStore) watch(interval time.Duration, stop <-chan struct{}, log *core.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			current := s.fileTimes()
+			changed := false
+			for i := range current {
+				if !current[i].Equal(s.modTimes[i]) {
+					changed = true
+				}
+			}
+			if !changed {
+				continue
+			}
+			if err := s.load(); err != nil {
+				log.Error("certificate reload failed, keeping the previous ones: %v", err)
+				s.modTimes = current
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// httpsRedirectServer answers every request with a redirect to the same URL
+// over HTTPS on the port of tlsAddr.
+func httpsRedirectServer(tlsAddr net.Addr) *fasthttp.Server {
+	_, port, _ := net.SplitHostPort(tlsAddr.String())
+	return &fasthttp.Server{
+		CloseOnShutdown: true,
+		Handler: func(ctx *fasthttp.RequestCtx) {
+			host := hostname(string(ctx.Host()))
+			if port != "443" {
+				host = net.JoinHostPort(host, port)
+			}
+			target := "https://" + host + string(ctx.RequestURI())
+
+			status := fasthttp.StatusMovedPermanently
+			if !ctx.IsGet() && !ctx.IsHead() {
+				status = fasthttp.StatusPermanentRedirect
+			}
+			ctx.Redirect(target, status)
+		},
+	}
+}
@@ -0,0 +1,314 @@
+package turbotest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/core"
+)
+
+// Engines holds the fakes installed by FakeEngines.
+type Engines struct {
+	Cache  *Cache
+	Queue  *Queue
+	Pubsub *Pubsub
+}
+
+// FakeEngines makes in-memory fakes the cache, queue and pubsub engines of
+// app and returns them. Nothing is written to disk and no goroutine is
+// started, so tests can inspect what handlers stored, enqueued and
+// published right after a request.
+//
+//	fakes := turbotest.FakeEngines(app)
+//	turbotest.New(t, app).Post("/signup").Body("ada@example.com").Expect().Status(202)
+//	fakes.Queue.Tasks("emails") // [][]byte{[]byte("ada@example.com")}
+func FakeEngines(app *TurboGo.App) *Engines {
+	e := &Engines{Cache: NewCache(), Queue: NewQueue(), Pubsub: NewPubsub()}
+	app.WithCacheEngine(e.Cache).WithQueueEngine(e.Queue).WithPubsubEngine(e.Pubsub)
+	return e
+}
+
+var (
+	_ core.Cache  = (*Cache)(nil)
+	_ core.Queue  = (*Queue)(nil)
+	_ core.Pubsub = (*Pubsub)(nil)
+)
+
+// Cache is an in-memory core.Cache. Entries expire when read after their
+// TTL; there is no background cleanup.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value   []byte
+	expires time.Time // zero for no expiry
+}
+
+// NewCache returns an empty cache.
+func NewCache() *Cache {
+	return &Cache{entries: make(map[string]cacheEntry)}
+}
+
+func (c *Cache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(key, value, ttl)
+}
+
+func (c *Cache) set(key string, value []byte, ttl time.Duration) {
+	e := cacheEntry{value: append([]byte(nil), value...)}
+	if ttl > 0 {
+		e.expires = time.Now().Add(ttl)
+	}
+	c.entries[key] = e
+}
+
+// lookup returns the live entry of key, dropping it if it has expired. The
+// caller holds c.mu.
+func (c *Cache) lookup(key string) (cacheEntry, bool) {
+	e, ok := c.entries[key]
+	if ok && !e.expires.IsZero() && !time.Now().Before(e.expires) {
+		delete(c.entries, key)
+		return cacheEntry{}, false
+	}
+	return e, ok
+}
+
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.lookup(key)
+	return e.value, ok
+}
+
+func (c *Cache) Delete(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.lookup(key)
+	delete(c.entries, key)
+	return ok
+}
+
+func (c *Cache) Exists(key string) bool {
+	_, ok := c.Get(key)
+	return ok
+}
+
+func (c *Cache) SetEx(key string, value []byte, seconds int) {
+	c.Set(key, value, time.Duration(seconds)*time.Second)
+}
+
+func (c *Cache) SetNX(key string, value []byte, ttl time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.lookup(key); ok {
+		return false
+	}
+	c.set(key, value, ttl)
+	return true
+}
+
+// TTL returns the time key has left, -1s for no expiry and -2s for a
+// missing key, like the built-in cache.
+func (c *Cache) TTL(key string) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.lookup(key)
+	switch {
+	case !ok:
+		return -2 * time.Second
+	case e.expires.IsZero():
+		return -1 * time.Second
+	}
+	return time.Until(e.expires)
+}
+
+func (c *Cache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		c.lookup(key)
+	}
+	return len(c.entries)
+}
+
+func (c *Cache) Range(fn func(key string, value []byte)) {
+	c.mu.Lock()
+	live := make(map[string][]byte, len(c.entries))
+	for key := range c.entries {
+		if e, ok := c.lookup(key); ok {
+			live[key] = e.value
+		}
+	}
+	c.mu.Unlock()
+	for key, value := range live {
+		fn(key, value)
+	}
+}
+
+// Close drops every entry.
+func (c *Cache) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+// Queue is an in-memory core.Queue that runs tasks synchronously: EnqueueAll
+// calls the queue's worker before returning, or keeps the task until a
+// worker is registered. Worker errors are kept for Errors instead of being
+// retried.
+type Queue struct {
+	mu      sync.Mutex
+	tasks   map[string][][]byte
+	pending map[string][][]byte
+	workers map[string]func([]byte) error
+	errs    map[string][]error
+}
+
+// NewQueue returns an empty queue.
+func NewQueue() *Queue {
+	return &Queue{
+		tasks:   make(map[string][][]byte),
+		pending: make(map[string][][]byte),
+		workers: make(map[string]func([]byte) error),
+		errs:    make(map[string][]error),
+	}
+}
+
+func (q *Queue) EnqueueAll(queue string, task []byte) error {
+	task = append([]byte(nil), task...)
+	q.mu.Lock()
+	q.tasks[queue] = append(q.tasks[queue], task)
+	worker := q.workers[queue]
+	if worker == nil {
+		q.pending[queue] = append(q.pending[queue], task)
+	}
+	q.mu.Unlock()
+
+	if worker != nil {
+		q.run(queue, worker, task)
+	}
+	return nil
+}
+
+// RegisterWorkerAll sets the worker of queue, replacing the previous one,
+// and runs the tasks waiting for it.
+func (q *Queue) RegisterWorkerAll(queue string, handler func([]byte) error) {
+	q.mu.Lock()
+	q.workers[queue] = handler
+	pending := q.pending[queue]
+	delete(q.pending, queue)
+	q.mu.Unlock()
+
+	for _, task := range pending {
+		q.run(queue, handler, task)
+	}
+}
+
+func (q *Queue) run(queue string, worker func([]byte) error, task []byte) {
+	if err := worker(task); err != nil {
+		q.mu.Lock()
+		q.errs[queue] = append(q.errs[queue], err)
+		q.mu.Unlock()
+	}
+}
+
+// Tasks returns every task enqueued to queue, in order, whether it has run
+// or not.
+func (q *Queue) Tasks(queue string) [][]byte {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([][]byte(nil), q.tasks[queue]...)
+}
+
+// Errors returns the errors the worker of queue returned.
+func (q *Queue) Errors(queue string) []error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]error(nil), q.errs[queue]...)
+}
+
+// Shutdown does nothing: tasks have run by the time EnqueueAll returns.
+func (q *Queue) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// Pubsub is an in-memory core.Pubsub that keeps every published message.
+// Like the built-in bus, a subscriber whose buffer is full misses messages
+// rather than blocking the publisher.
+type Pubsub struct {
+	mu        sync.Mutex
+	published map[string][][]byte
+	subs      map[string][]chan []byte
+	closed    bool
+}
+
+// SubscriberBuffer is the channel capacity of each Pubsub subscription.
+const SubscriberBuffer = 1000
+
+// NewPubsub returns a bus without subscribers.
+func NewPubsub() *Pubsub {
+	return &Pubsub{
+		published: make(map[string][][]byte),
+		subs:      make(map[string][]chan []byte),
+	}
+}
+
+func (p *Pubsub) PublishAll(topic string, data []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return errors.New("turbotest: pubsub is closed")
+	}
+	data = append([]byte(nil), data...)
+	p.published[topic] = append(p.published[topic], data)
+	for _, ch := range p.subs[topic] {
+		select {
+		case ch <- data:
+		default:
+		}
+	}
+	return nil
+}
+
+// SubscribeAll returns a channel receiving the messages published to topic
+// from now on. It is closed by Close.
+func (p *Pubsub) SubscribeAll(topic string) <-chan []byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ch := make(chan []byte, SubscriberBuffer)
+	if p.closed {
+		close(ch)
+		return ch
+	}
+	p.subs[topic] = append(p.subs[topic], ch)
+	return ch
+}
+
+// Messages returns every message published to topic, in order.
+func (p *Pubsub) Messages(topic string) [][]byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([][]byte(nil), p.published[topic]...)
+}
+
+// Close closes every subscription; later publishes fail.
+func (p *Pubsub) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	p.closed = true
+	for _, chans := range p.subs {
+		for _, ch := range chans {
+			close(ch)
+		}
+	}
+	p.subs = nil
+}
@@ -0,0 +1,268 @@
+// Package turbotest runs requests against a TurboGo app in memory, through
+// App.Test, and checks the responses with chained assertions.
+//
+//	func TestGetUser(t *testing.T) {
+//		app := TurboGo.New()
+//		app.Get("/users/:id", getUser)
+//
+//		turbotest.New(t, app).
+//			Get("/users/1").
+//			Header("Accept", "application/json").
+//			Expect().
+//			Status(200).
+//			Header("Content-Type", "application/json").
+//			JSON(`{"id":1,"name":"Ada"}`)
+//	}
+//
+// Failed assertions are reported with t.Errorf and the chain goes on, so one
+// run shows every mismatch; a request that gets no response stops the test.
+package turbotest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dziqha/TurboGo"
+)
+
+// Client sends requests to one app. Headers set on the client are sent with
+// every request.
+type Client struct {
+	t       testing.TB
+	app     *TurboGo.App
+	header  http.Header
+	timeout time.Duration
+}
+
+// New returns a client for app reporting to t.
+func New(t testing.TB, app *TurboGo.App) *Client {
+	return &Client{t: t, app: app, header: http.Header{}, timeout: TurboGo.DefaultTestTimeout}
+}
+
+// Header sets a header sent with every request of the client.
+func (c *Client) Header(key, value string) *Client {
+	c.header.Set(key, value)
+	return c
+}
+
+// Timeout changes how long a request may take; zero or less means no limit.
+func (c *Client) Timeout(d time.Duration) *Client {
+	c.timeout = d
+	return c
+}
+
+// Request starts a request with any method. target is a path with an
+// optional query, e.g. "/users?page=2".
+func (c *Client) Request(method, target string) *Request {
+	req := httptest.NewRequest(method, target, nil)
+	for k, v := range c.header {
+		req.Header[k] = append([]string(nil), v...)
+	}
+	return &Request{c: c, req: req}
+}
+
+func (c *Client) Get(target string) *Request     { return c.Request(http.MethodGet, target) }
+func (c *Client) Head(target string) *Request    { return c.Request(http.MethodHead, target) }
+func (c *Client) Post(target string) *Request    { return c.Request(http.MethodPost, target) }
+func (c *Client) Put(target string) *Request     { return c.Request(http.MethodPut, target) }
+func (c *Client) Patch(target string) *Request   { return c.Request(http.MethodPatch, target) }
+func (c *Client) Delete(target string) *Request  { return c.Request(http.MethodDelete, target) }
+func (c *Client) Options(target string) *Request { return c.Request(http.MethodOptions, target) }
+
+// Request is a request being built. Finish it with Expect.
+type Request struct {
+	c   *Client
+	req *http.Request
+}
+
+// Header sets a request header.
+func (r *Request) Header(key, value string) *Request {
+	r.req.Header.Set(key, value)
+	return r
+}
+
+// Host sets the Host header, for host-routed apps.
+func (r *Request) Host(host string) *Request {
+	r.req.Host = host
+	return r
+}
+
+// Query adds a query parameter.
+func (r *Request) Query(key, value string) *Request {
+	q := r.req.URL.Query()
+	q.Add(key, value)
+	r.req.URL.RawQuery = q.Encode()
+	return r
+}
+
+// Body sets the raw request body.
+func (r *Request) Body(body string) *Request {
+	r.setBody([]byte(body))
+	return r
+}
+
+// JSON sets v, encoded as JSON, as the body with its Content-Type.
+func (r *Request) JSON(v any) *Request {
+	data, err := json.Marshal(v)
+	if err != nil {
+		r.c.t.Helper()
+		r.c.t.Fatalf("turbotest: encode JSON body: %v", err)
+	}
+	r.setBody(data)
+	r.req.Header.Set("Content-Type", "application/json")
+	return r
+}
+
+// Form sets values as an URL-encoded form body with its Content-Type.
+func (r *Request) Form(values url.Values) *Request {
+	r.setBody([]byte(values.Encode()))
+	r.req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func (r *Request) setBody(body []byte) {
+	r.req.Body = io.NopCloser(bytes.NewReader(body))
+	r.req.ContentLength = int64(len(body))
+}
+
+// Expect sends the request and returns the response for assertions. It
+// stops the test if the app does not answer.
+func (r *Request) Expect() *Response {
+	r.c.t.Helper()
+	res, err := r.c.app.Test(r.req, r.c.timeout)
+	if err != nil {
+		r.c.t.Fatalf("turbotest: %s %s: %v", r.req.Method, r.req.URL, err)
+	}
+	res.Request = r.req
+	body, _ := io.ReadAll(res.Body)
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	return &Response{t: r.c.t, Response: res, body: body}
+}
+
+// Response is a received response. The assertion methods return it so they
+// can be chained.
+type Response struct {
+	*http.Response
+	t    testing.TB
+	body []byte
+}
+
+// Status asserts the status code.
+func (r *Response) Status(code int) *Response {
+	r.t.Helper()
+	if r.StatusCode != code {
+		r.t.Errorf("turbotest: status of %s %s: got %d, want %d", r.Request.Method, r.Request.URL, r.StatusCode, code)
+	}
+	return r
+}
+
+// Header asserts the value of a response header.
+func (r *Response) Header(key, value string) *Response {
+	r.t.Helper()
+	if got := r.Response.Header.Get(key); got != value {
+		r.t.Errorf("turbotest: header %s: got %q, want %q", key, got, value)
+	}
+	return r
+}
+
+// NoHeader asserts that a response header is absent.
+func (r *Response) NoHeader(key string) *Response {
+	r.t.Helper()
+	if got := r.Response.Header.Values(key); len(got) > 0 {
+		r.t.Errorf("turbotest: header %s: got %q, want none", key, got)
+	}
+	return r
+}
+
+// Body asserts the whole body.
+func (r *Response) Body(body string) *Response {
+	r.t.Helper()
+	if string(r.body) != body {
+		r.t.Errorf("turbotest: body: got %q, want %q", r.body, body)
+	}
+	return r
+}
+
+// BodyContains asserts that the body contains s.
+func (r *Response) BodyContains(s string) *Response {
+	r.t.Helper()
+	if !strings.Contains(string(r.body), s) {
+		r.t.Errorf("turbotest: body %q does not contain %q", r.body, s)
+	}
+	return r
+}
+
+// JSON asserts that the body is JSON equal to expected, given either as a
+// JSON string or as a value to encode. Key order and spacing are ignored.
+func (r *Response) JSON(expected any) *Response {
+	r.t.Helper()
+	var want []byte
+	switch v := expected.(type) {
+	case string:
+		want = []byte(v)
+	case []byte:
+		want = v
+	default:
+		var err error
+		if want, err = json.Marshal(v); err != nil {
+			r.t.Fatalf("turbotest: encode expected JSON: %v", err)
+		}
+	}
+	var wantValue, gotValue any
+	if err := json.Unmarshal(want, &wantValue); err != nil {
+		r.t.Fatalf("turbotest: expected JSON is invalid: %v", err)
+	}
+	if err := json.Unmarshal(r.body, &gotValue); err != nil {
+		r.t.Errorf("turbotest: body is not JSON: %v\n%s", err, r.body)
+		return r
+	}
+	if !reflect.DeepEqual(wantValue, gotValue) {
+		r.t.Errorf("turbotest: JSON body differs\ngot:  %s\nwant: %s", r.body, want)
+	}
+	return r
+}
+
+// Decode unmarshals the JSON body into v.
+func (r *Response) Decode(v any) *Response {
+	r.t.Helper()
+	if err := json.Unmarshal(r.body, v); err != nil {
+		r.t.Errorf("turbotest: decode JSON body: %v", err)
+	}
+	return r
+}
+
+// Text returns the body.
+func (r *Response) Text() string {
+	return string(r.body)
+}
+
+// WithEngines enables the cache, queue and pubsub engines of app for one
+// test. The queue and pubsub files live in a temporary directory, so tests
+// never touch data/ or each other's tasks, and the app is shut down when the
+// test ends. Workers and subscribers are registered through app.EngineCtx as
+// usual. FakeEngines installs in-memory fakes instead.
+//
+//	app := turbotest.WithEngines(t, TurboGo.New())
+//	app.EngineCtx.Queue.RegisterWorkerAll("emails", sendEmail)
+func WithEngines(t testing.TB, app *TurboGo.App) *TurboGo.App {
+	dir := t.TempDir()
+	storage := TurboGo.StorageOptions{DataDir: dir}
+	app.WithCache().WithQueue(storage).WithPubsub(storage)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := app.Shutdown(ctx); err != nil {
+			t.Errorf("turbotest: shutdown: %v", err)
+		}
+	})
+	return app
+}
@@ -0,0 +1,199 @@
+package turbotest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dziqha/TurboGo"
+	"github.com/Dziqha/TurboGo/core"
+	"github.com/Dziqha/TurboGo/turbotest"
+)
+
+// recorder collects the failures reported through Errorf instead of
+// failing the test.
+type recorder struct {
+	testing.TB
+	errs []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+func userApp() *TurboGo.App {
+	core.DisableLogger = true
+	app := TurboGo.New()
+	app.Get("/users/:id", func(c *core.Context) {
+		c.Ctx.Response.Header.Set("X-Request", c.Query("trace"))
+		c.JSON(200, map[string]any{"id": c.Param("id"), "name": "Ada"})
+	})
+	return app
+}
+
+func TestClient_PassingAssertions(t *testing.T) {
+	rec := &recorder{TB: t}
+	var user struct{ Name string }
+	turbotest.New(rec, userApp()).
+		Get("/users/1").
+		Query("trace", "abc").
+		Expect().
+		Status(200).
+		Header("X-Request", "abc").
+		NoHeader("X-Missing").
+		BodyContains(`"name":"Ada"`).
+		JSON(`{"name": "Ada", "id": "1"}`).
+		JSON(map[string]string{"id": "1", "name": "Ada"}).
+		Decode(&user)
+
+	if len(rec.errs) > 0 {
+		t.Errorf("unexpected failures: %q", rec.errs)
+	}
+	if user.Name != "Ada" {
+		t.Errorf("decoded name = %q, want Ada", user.Name)
+	}
+}
+
+func TestClient_ReportsEveryMismatch(t *testing.T) {
+	rec := &recorder{TB: t}
+	turbotest.New(rec, userApp()).
+		Get("/users/1").
+		Expect().
+		Status(201).
+		Header("Content-Type", "text/plain").
+		NoHeader("Content-Type").
+		Body("nope").
+		BodyContains("Grace").
+		JSON(`{"id":"2","name":"Ada"}`)
+
+	want := []string{"status", "header Content-Type", "header Content-Type", "body", "does not contain", "JSON body differs"}
+	if len(rec.errs) != len(want) {
+		t.Fatalf("got %d failures, want %d: %q", len(rec.errs), len(want), rec.errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(rec.errs[i], w) {
+			t.Errorf("failure %d = %q, want it to mention %q", i, rec.errs[i], w)
+		}
+	}
+}
+
+func TestFakeEngines(t *testing.T) {
+	core.DisableLogger = true
+	app := TurboGo.New()
+	fakes := turbotest.FakeEngines(app)
+
+	var sent []string
+	app.EngineCtx.Queue.RegisterWorkerAll("emails", func(task []byte) error {
+		sent = append(sent, string(task))
+		if string(task) == "bounce@example.com" {
+			return errors.New("bounced")
+		}
+		return nil
+	})
+	events := app.EngineCtx.Pubsub.SubscribeAll("user.created")
+
+	app.Post("/signup", func(c *core.Context) error {
+		email := c.Ctx.PostBody()
+		c.Cache.Memory.Set("last", email, time.Minute)
+		if err := c.MustQueue().EnqueueAll("emails", email); err != nil {
+			return err
+		}
+		if err := c.MustPubsub().PublishAll("user.created", email); err != nil {
+			return err
+		}
+		c.Text(202, "queued")
+		return nil
+	})
+	var hits int
+	app.Get("/count", func(c *core.Context) {
+		hits++
+		c.Text(200, fmt.Sprint(hits))
+	}).Cache(time.Minute)
+
+	client := turbotest.New(t, app)
+	client.Post("/signup").Body("ada@example.com").Expect().Status(202)
+	client.Post("/signup").Body("bounce@example.com").Expect().Status(202)
+
+	// The worker has run by the time the request returns.
+	if got := strings.Join(sent, ","); got != "ada@example.com,bounce@example.com" {
+		t.Errorf("worker got %q", got)
+	}
+	if tasks := fakes.Queue.Tasks("emails"); len(tasks) != 2 {
+		t.Errorf("got %d tasks, want 2", len(tasks))
+	}
+	if errs := fakes.Queue.Errors("emails"); len(errs) != 1 || errs[0].Error() != "bounced" {
+		t.Errorf("worker errors = %v", errs)
+	}
+	if msgs := fakes.Pubsub.Messages("user.created"); len(msgs) != 2 || string(msgs[0]) != "ada@example.com" {
+		t.Errorf("published %q", msgs)
+	}
+	if msg := <-events; string(msg) != "ada@example.com" {
+		t.Errorf("subscriber got %q", msg)
+	}
+	<-events
+	if last, ok := fakes.Cache.Get("last"); !ok || string(last) != "bounce@example.com" {
+		t.Errorf("cache has %q, %v", last, ok)
+	}
+
+	// Route caching stores responses in the fake cache.
+	client.Get("/count").Expect().Body("1").Header("X-Cache", "MISS")
+	client.Get("/count").Expect().Body("1").Header("X-Cache", "HIT")
+	if n := fakes.Cache.Size(); n != 2 {
+		t.Errorf("cache holds %d entries, want 2", n)
+	}
+
+	if err := app.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if _, ok := <-events; ok {
+		t.Errorf("subscription is still open after shutdown")
+	}
+	if err := fakes.Pubsub.PublishAll("user.created", nil); err == nil {
+		t.Errorf("publish after shutdown succeeded")
+	}
+}
+
+func TestQueue_RunsPendingTasksOnRegister(t *testing.T) {
+	q := turbotest.NewQueue()
+	q.EnqueueAll("jobs", []byte("a"))
+	q.EnqueueAll("jobs", []byte("b"))
+
+	var ran []string
+	q.RegisterWorkerAll("jobs", func(task []byte) error {
+		ran = append(ran, string(task))
+		return nil
+	})
+	q.EnqueueAll("jobs", []byte("c"))
+
+	if got := strings.Join(ran, ""); got != "abc" {
+		t.Errorf("ran %q, want abc", got)
+	}
+}
+
+func TestCache_Expiry(t *testing.T) {
+	c := turbotest.NewCache()
+	c.Set("short", []byte("x"), time.Millisecond)
+	c.Set("forever", []byte("y"), 0)
+
+	if !c.SetNX("new", []byte("1"), 0) || c.SetNX("new", []byte("2"), 0) {
+		t.Errorf("SetNX must only set missing keys")
+	}
+	if ttl := c.TTL("forever"); ttl != -time.Second {
+		t.Errorf("TTL of a key without expiry = %v", ttl)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if c.Exists("short") {
+		t.Errorf("expired key still exists")
+	}
+	if ttl := c.TTL("short"); ttl != -2*time.Second {
+		t.Errorf("TTL of a missing key = %v", ttl)
+	}
+	if n := c.Size(); n != 2 {
+		t.Errorf("size = %d, want 2", n)
+	}
+}
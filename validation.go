@@ -0,0 +1,33 @@
+package TurboGo
+
+import "github.com/Dziqha/TurboGo/core"
+
+// ===== VALIDATION =====
+
+// ValidationRule registers a rule for `validate` tags checked by
+// Context.Validate and Context.BindAndValidate, with its English message.
+//
+//	slug := regexp.MustCompile(`^[a-z0-9-]+$`)
+//	app.ValidationRule("slug", func(v reflect.Value, _ string) bool {
+//		return slug.MatchString(v.String())
+//	}, "must contain only lowercase letters, digits and dashes")
+//
+// It panics on an empty or reserved name or a nil rule.
+func (a *App) ValidationRule(name string, rule core.Rule, message string) *App {
+	a.validator.Register(name, rule, message)
+	return a
+}
+
+// ValidationMessages adds or replaces validation messages for a language,
+// chosen by the request's Accept-Language. Keys are rule names, optionally
+// suffixed with ".string", ".number" or ".items"; see core.Validator.
+//
+//	app.ValidationMessages("id", map[string]string{
+//		"required":   "wajib diisi",
+//		"email":      "harus berupa alamat email yang valid",
+//		"min.string": "minimal {param} karakter",
+//	})
+func (a *App) ValidationMessages(lang string, messages map[string]string) *App {
+	a.validator.Messages(lang, messages)
+	return a
+}